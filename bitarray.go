@@ -0,0 +1,151 @@
+package datastruct
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrOutOfRange is returned when a bit index is outside of a BitArray
+	ErrOutOfRange = errors.New("Index out of range")
+)
+
+// BitArray is a fixed length array of bits backed by Bits. Unlike Bits it knows its length, every
+// access is bounds checked, and the padding bits in the last word are always zero. It can be grown
+// with Resize and Append. The zero value is an empty array.
+type BitArray struct {
+	bits Bits
+	n    int
+}
+
+// NewBitArray creates a new array of n bits, all clear.
+func NewBitArray(n int) *BitArray {
+	n = max(n, 0)
+	return &BitArray{NewBits(n), n}
+}
+
+// BitArrayFromBits creates a new array of the first n bits of b. It returns an error if n is more
+// than the number of bits in b.
+func BitArrayFromBits(b Bits, n int) (*BitArray, error) {
+	if n < 0 || n > len(b)*64 {
+		return nil, fmt.Errorf("%w: length %d not in [0, %d]", ErrOutOfRange, n, len(b)*64)
+	}
+	res := &BitArray{slices.Clone(b[:(n+63)/64]), n}
+	res.trim()
+	return res, nil
+}
+
+// BitArrayFromSlice creates a new array with the contents of the boolean slice.
+func BitArrayFromSlice(in []bool) *BitArray {
+	return &BitArray{BitsFromSlice(in), len(in)}
+}
+
+// Len returns the number of bits in the array.
+func (a *BitArray) Len() int {
+	return a.n
+}
+
+// Get returns the state of bit i.
+func (a *BitArray) Get(i int) (bool, error) {
+	if err := a.check(i); err != nil {
+		return false, err
+	}
+	return a.bits.Get(i), nil
+}
+
+// Set sets bit i to true.
+func (a *BitArray) Set(i int) error {
+	if err := a.check(i); err != nil {
+		return err
+	}
+	a.bits.Set(i)
+	return nil
+}
+
+// Clear sets bit i to false.
+func (a *BitArray) Clear(i int) error {
+	if err := a.check(i); err != nil {
+		return err
+	}
+	a.bits.Clear(i)
+	return nil
+}
+
+// Resize changes the length of the array to n bits. New bits are clear, and bits beyond n are
+// discarded.
+func (a *BitArray) Resize(n int) error {
+	if n < 0 {
+		return fmt.Errorf("%w: length %d is negative", ErrOutOfRange, n)
+	}
+	nw := (n + 63) / 64
+	if nw > len(a.bits) {
+		a.bits = append(a.bits, make(Bits, nw-len(a.bits))...)
+	} else {
+		clear(a.bits[nw:])
+		a.bits = a.bits[:nw]
+	}
+	a.n = n
+	a.trim()
+	return nil
+}
+
+// Append adds the bits in v to the end of the array.
+func (a *BitArray) Append(v ...bool) {
+	i := a.n
+	a.Resize(a.n + len(v))
+	for _, b := range v {
+		if b {
+			a.bits.Set(i)
+		}
+		i++
+	}
+}
+
+// Slice returns the array as a slice of bool with exactly Len entries.
+func (a *BitArray) Slice() []bool {
+	res := make([]bool, a.n)
+	for i := range res {
+		res[i] = a.bits.Get(i)
+	}
+	return res
+}
+
+// Bits returns a copy of the array as Bits. The padding bits are zero.
+func (a *BitArray) Bits() Bits {
+	return slices.Clone(a.bits)
+}
+
+// Copy makes a copy of the array.
+func (a *BitArray) Copy() *BitArray {
+	return &BitArray{slices.Clone(a.bits), a.n}
+}
+
+// String returns a string representation of the array as 0s and 1s, bit 0 first.
+func (a *BitArray) String() string {
+	var sb strings.Builder
+	for i := range a.n {
+		if a.bits.Get(i) {
+			sb.WriteByte('1')
+		} else {
+			sb.WriteByte('0')
+		}
+	}
+	return sb.String()
+}
+
+// check returns an error if i isn't a valid bit index.
+func (a *BitArray) check(i int) error {
+	if i < 0 || i >= a.n {
+		return fmt.Errorf("%w: %d not in [0, %d)", ErrOutOfRange, i, a.n)
+	}
+	return nil
+}
+
+// trim clears the padding bits in the last word.
+func (a *BitArray) trim() {
+	if r := a.n % 64; r != 0 {
+		a.bits[len(a.bits)-1] &= (uint64(1) << r) - 1
+	}
+}
@@ -1,5 +1,10 @@
 package datastruct
 
+import (
+	"iter"
+	"math/bits"
+)
+
 // https://medium.com/@val_deleplace/7-ways-to-implement-a-bit-set-in-go-91650229b386 has a good
 // breakdown of the various ways of implementing this.
 
@@ -71,6 +76,9 @@ func BitsFromSlice(in []bool) Bits {
 		b[i] = word
 	}
 	// Remainder
+	if ip == lb {
+		return b
+	}
 	var word uint64
 	mask := uint64(1)
 	for j := 0; ip < lb; ip, j = ip+1, j+1 {
@@ -83,3 +91,276 @@ func BitsFromSlice(in []bool) Bits {
 
 	return b
 }
+
+// The logical operations below work a word at a time. Where the operands differ in length, the
+// shorter is treated as if it were padded with zero words.
+
+// And returns a new Bits holding b AND o.
+func (b Bits) And(o Bits) Bits {
+	res := make(Bits, max(len(b), len(o)))
+	res.SetAnd(b, o)
+	return res
+}
+
+// Or returns a new Bits holding b OR o.
+func (b Bits) Or(o Bits) Bits {
+	res := make(Bits, max(len(b), len(o)))
+	res.SetOr(b, o)
+	return res
+}
+
+// Xor returns a new Bits holding b XOR o.
+func (b Bits) Xor(o Bits) Bits {
+	res := make(Bits, max(len(b), len(o)))
+	res.SetXor(b, o)
+	return res
+}
+
+// AndNot returns a new Bits holding b AND NOT o.
+func (b Bits) AndNot(o Bits) Bits {
+	res := make(Bits, max(len(b), len(o)))
+	res.SetAndNot(b, o)
+	return res
+}
+
+// Not returns a new Bits holding NOT b. Note that every bit in the last word is flipped, including
+// any beyond the length passed to NewBits.
+func (b Bits) Not() Bits {
+	res := make(Bits, len(b))
+	res.SetNot(b)
+	return res
+}
+
+// AndWith sets b to b AND o.
+func (b Bits) AndWith(o Bits) {
+	b.SetAnd(b, o)
+}
+
+// OrWith sets b to b OR o. Bits in o beyond the end of b are ignored.
+func (b Bits) OrWith(o Bits) {
+	b.SetOr(b, o)
+}
+
+// XorWith sets b to b XOR o. Bits in o beyond the end of b are ignored.
+func (b Bits) XorWith(o Bits) {
+	b.SetXor(b, o)
+}
+
+// AndNotWith sets b to b AND NOT o.
+func (b Bits) AndNotWith(o Bits) {
+	b.SetAndNot(b, o)
+}
+
+// NotWith sets b to NOT b.
+func (b Bits) NotWith() {
+	b.SetNot(b)
+}
+
+// SetAnd sets b to x AND y. Bits beyond the end of b are ignored.
+func (b Bits) SetAnd(x, y Bits) {
+	for i := range b {
+		b[i] = bitsWord(x, i) & bitsWord(y, i)
+	}
+}
+
+// SetOr sets b to x OR y. Bits beyond the end of b are ignored.
+func (b Bits) SetOr(x, y Bits) {
+	for i := range b {
+		b[i] = bitsWord(x, i) | bitsWord(y, i)
+	}
+}
+
+// SetXor sets b to x XOR y. Bits beyond the end of b are ignored.
+func (b Bits) SetXor(x, y Bits) {
+	for i := range b {
+		b[i] = bitsWord(x, i) ^ bitsWord(y, i)
+	}
+}
+
+// SetAndNot sets b to x AND NOT y. Bits beyond the end of b are ignored.
+func (b Bits) SetAndNot(x, y Bits) {
+	for i := range b {
+		b[i] = bitsWord(x, i) &^ bitsWord(y, i)
+	}
+}
+
+// SetNot sets b to NOT x. Bits beyond the end of b are ignored.
+func (b Bits) SetNot(x Bits) {
+	for i := range b {
+		b[i] = ^bitsWord(x, i)
+	}
+}
+
+// Equal returns true if b and o have the same bits set.
+func (b Bits) Equal(o Bits) bool {
+	for i := range max(len(b), len(o)) {
+		if bitsWord(b, i) != bitsWord(o, i) {
+			return false
+		}
+	}
+	return true
+}
+
+// Any returns true if any bit is set.
+func (b Bits) Any() bool {
+	for _, w := range b {
+		if w != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// All returns true if every bit, including any beyond the length passed to NewBits, is set.
+func (b Bits) All() bool {
+	for _, w := range b {
+		if w != ^uint64(0) {
+			return false
+		}
+	}
+	return true
+}
+
+// None returns true if no bit is set.
+func (b Bits) None() bool {
+	return !b.Any()
+}
+
+// SetRange sets bits i up to, but not including, j to true.
+func (b Bits) SetRange(i, j int) {
+	b.rangeOp(i, j, func(w *uint64, m uint64) { *w |= m })
+}
+
+// ClearRange sets bits i up to, but not including, j to false.
+func (b Bits) ClearRange(i, j int) {
+	b.rangeOp(i, j, func(w *uint64, m uint64) { *w &^= m })
+}
+
+// FlipRange inverts bits i up to, but not including, j.
+func (b Bits) FlipRange(i, j int) {
+	b.rangeOp(i, j, func(w *uint64, m uint64) { *w ^= m })
+}
+
+// rangeOp applies op to the words spanning bits [i, j) with a mask of the bits in range.
+func (b Bits) rangeOp(i, j int, op func(*uint64, uint64)) {
+	if i >= j {
+		return
+	}
+	p, q := i/64, (j-1)/64
+	lo := ^uint64(0) << uint(i%64)
+	hi := ^uint64(0) >> uint(63-(j-1)%64)
+	if p == q {
+		op(&b[p], lo&hi)
+		return
+	}
+	op(&b[p], lo)
+	for k := p + 1; k < q; k++ {
+		op(&b[k], ^uint64(0))
+	}
+	op(&b[q], hi)
+}
+
+// Lsh returns a new Bits, the same length as b, with the bits moved n places towards the end.
+// Bits moved past the end are lost. A negative n shifts the other way.
+func (b Bits) Lsh(n int) Bits {
+	if n < 0 {
+		return b.Rsh(-n)
+	}
+	res := make(Bits, len(b))
+	ws, bs := n/64, uint(n%64)
+	for i := len(b) - 1; i >= ws; i-- {
+		w := b[i-ws] << bs
+		if bs != 0 && i-ws > 0 {
+			w |= b[i-ws-1] >> (64 - bs)
+		}
+		res[i] = w
+	}
+	return res
+}
+
+// Rsh returns a new Bits, the same length as b, with the bits moved n places towards the start.
+// Bits moved past the start are lost. A negative n shifts the other way.
+func (b Bits) Rsh(n int) Bits {
+	if n < 0 {
+		return b.Lsh(-n)
+	}
+	res := make(Bits, len(b))
+	ws, bs := n/64, uint(n%64)
+	for i := 0; i+ws < len(b); i++ {
+		w := b[i+ws] >> bs
+		if bs != 0 && i+ws+1 < len(b) {
+			w |= b[i+ws+1] << (64 - bs)
+		}
+		res[i] = w
+	}
+	return res
+}
+
+// Count returns the number of bits set.
+func (b Bits) Count() int {
+	n := 0
+	for _, w := range b {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// NextSet returns the index of the first set bit at or after i, or -1 if there isn't one.
+func (b Bits) NextSet(i int) int {
+	i = max(i, 0)
+	p := i / 64
+	if p >= len(b) {
+		return -1
+	}
+	w := b[p] >> uint(i%64)
+	if w != 0 {
+		return i + bits.TrailingZeros64(w)
+	}
+	for p++; p < len(b); p++ {
+		if b[p] != 0 {
+			return p*64 + bits.TrailingZeros64(b[p])
+		}
+	}
+	return -1
+}
+
+// NextClear returns the index of the first clear bit at or after i, or -1 if there isn't one.
+func (b Bits) NextClear(i int) int {
+	i = max(i, 0)
+	p := i / 64
+	if p >= len(b) {
+		return -1
+	}
+	w := ^b[p] >> uint(i%64)
+	if w != 0 {
+		return i + bits.TrailingZeros64(w)
+	}
+	for p++; p < len(b); p++ {
+		if b[p] != ^uint64(0) {
+			return p*64 + bits.TrailingZeros64(^b[p])
+		}
+	}
+	return -1
+}
+
+// Ones returns an iterator over the indices of the set bits in ascending order.
+func (b Bits) Ones() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for p, w := range b {
+			for w != 0 {
+				if !yield(p*64 + bits.TrailingZeros64(w)) {
+					return
+				}
+				w &= w - 1
+			}
+		}
+	}
+}
+
+// bitsWord returns word i of b, or 0 if b is too short.
+func bitsWord(b Bits, i int) uint64 {
+	if i < len(b) {
+		return b[i]
+	}
+	return 0
+}
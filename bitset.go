@@ -0,0 +1,209 @@
+package datastruct
+
+import "fmt"
+
+// BitSet represents a set of non-negative integer elements using Bits, one bit per possible
+// element. For small dense ranges of elements it uses far less memory than Set, and the set
+// operations work a word at a time. It grows as needed to hold the largest element added.
+type BitSet struct {
+	bits Bits
+}
+
+// NewBitSet returns a new set with the provided elements in it. Negative elements are ignored.
+func NewBitSet(elts ...int) *BitSet {
+	res := &BitSet{}
+	for _, e := range elts {
+		res.Add(e)
+	}
+	return res
+}
+
+// BitSetFromSet returns a new BitSet with the elements of s. Negative elements are ignored.
+func BitSetFromSet(s Set) *BitSet {
+	res := &BitSet{}
+	for e, v := range s {
+		if v {
+			res.Add(e)
+		}
+	}
+	return res
+}
+
+// ToSet returns a new Set with the elements of the set.
+func (s *BitSet) ToSet() Set {
+	return NewSet(s.Slice()...)
+}
+
+// Add adds the element e to the set and returns true if it wasn't already in the set. Adding
+// an element when it already exists, or a negative element, is a no-op signified by false.
+func (s *BitSet) Add(e int) bool {
+	if e < 0 {
+		return false
+	}
+	if n := e/64 + 1; n > len(s.bits) {
+		s.grow(n)
+	}
+	if s.bits.Get(e) {
+		return false
+	}
+	s.bits.Set(e)
+	return true
+}
+
+// Remove removes element e from the set and returns true if it was in the set.
+// Removing a non-existent element is a no-op signified by false.
+func (s *BitSet) Remove(e int) bool {
+	if !s.Element(e) {
+		return false
+	}
+	s.bits.Clear(e)
+	return true
+}
+
+// Element returns true if the set contains the element e.
+func (s *BitSet) Element(e int) bool {
+	if e < 0 || e/64 >= len(s.bits) {
+		return false
+	}
+	return s.bits.Get(e)
+}
+
+// Empty returns true if the set is the empty set.
+func (s *BitSet) Empty() bool {
+	return s.bits.None()
+}
+
+// Len returns the number of elements in the set.
+func (s *BitSet) Len() int {
+	return s.bits.Count()
+}
+
+// Copy makes a copy of the set.
+func (s *BitSet) Copy() *BitSet {
+	res := &BitSet{make(Bits, len(s.bits))}
+	copy(res.bits, s.bits)
+	return res
+}
+
+// Purge releases the storage for trailing words with no elements in them.
+func (s *BitSet) Purge() {
+	n := len(s.bits)
+	for n > 0 && s.bits[n-1] == 0 {
+		n--
+	}
+	b := make(Bits, n)
+	copy(b, s.bits)
+	s.bits = b
+}
+
+// Union returns a new set containing the union of the set and b (OR).
+func (s *BitSet) Union(b *BitSet) *BitSet {
+	res := s.Copy()
+	res.UnionWith(b)
+	return res
+}
+
+// Intersection returns a new set containing the intersection of the set and b (AND).
+func (s *BitSet) Intersection(b *BitSet) *BitSet {
+	res := s.Copy()
+	res.IntersectWith(b)
+	return res
+}
+
+// Difference returns a new set containing only the elements in either the set or b but not in both (XOR).
+func (s *BitSet) Difference(b *BitSet) *BitSet {
+	res := s.Copy()
+	res.DifferenceWith(b)
+	return res
+}
+
+// Sub returns a new set containing the elements in the set which are not in b (SUB).
+func (s *BitSet) Sub(b *BitSet) *BitSet {
+	res := s.Copy()
+	res.SubWith(b)
+	return res
+}
+
+// Contains returns true if b is completely contained in the set.
+func (s *BitSet) Contains(b *BitSet) bool {
+	for i, w := range b.bits {
+		var sw uint64
+		if i < len(s.bits) {
+			sw = s.bits[i]
+		}
+		if w&^sw != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Disjoint returns true if the set and b share no elements in common.
+func (s *BitSet) Disjoint(b *BitSet) bool {
+	n := min(len(s.bits), len(b.bits))
+	for i := 0; i < n; i++ {
+		if s.bits[i]&b.bits[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// UnionWith adds the elements of b to the set (OR).
+func (s *BitSet) UnionWith(b *BitSet) {
+	if len(b.bits) > len(s.bits) {
+		s.grow(len(b.bits))
+	}
+	s.bits.OrWith(b.bits)
+}
+
+// IntersectWith removes the elements from the set that aren't in b (AND).
+func (s *BitSet) IntersectWith(b *BitSet) {
+	s.bits.AndWith(b.bits)
+}
+
+// DifferenceWith leaves only the elements in either the set or b but not in both (XOR).
+func (s *BitSet) DifferenceWith(b *BitSet) {
+	if len(b.bits) > len(s.bits) {
+		s.grow(len(b.bits))
+	}
+	s.bits.XorWith(b.bits)
+}
+
+// SubWith removes the elements in b from the set (SUB).
+func (s *BitSet) SubWith(b *BitSet) {
+	s.bits.AndNotWith(b.bits)
+}
+
+// String returns a string representation of the set in ascending order.
+func (s *BitSet) String() string {
+	res := "{"
+	for i, e := range s.Slice() {
+		if i == 0 {
+			res += fmt.Sprintf("%d", e)
+		} else {
+			res += fmt.Sprintf(", %d", e)
+		}
+	}
+	return res + "}"
+}
+
+// Slice returns a slice representation of the set in ascending order.
+func (s *BitSet) Slice() []int {
+	res := make([]int, 0, s.Len())
+	for e := range s.bits.Ones() {
+		res = append(res, e)
+	}
+	return res
+}
+
+// grow extends the set to n words.
+func (s *BitSet) grow(n int) {
+	if n <= cap(s.bits) {
+		s.bits = s.bits[:n]
+		return
+	}
+	b := make(Bits, n, max(n, 2*cap(s.bits)))
+	copy(b, s.bits)
+	s.bits = b
+}
@@ -0,0 +1,143 @@
+package datastruct
+
+import (
+	"encoding/binary"
+	"math"
+	"slices"
+)
+
+// BloomFilter is a probabilistic set of integers. Element never returns false for an element that
+// has been added, but may return true for one that hasn't, at a rate determined by the filter's
+// size. Elements can't be removed. Filters with the same size, from any process, can be merged.
+type BloomFilter struct {
+	bits Bits
+	m    uint64 // number of bits
+	k    int    // number of hashes
+}
+
+// NewBloomFilter creates a new filter sized to hold n elements with a false positive rate of p.
+func NewBloomFilter(n int, p float64) *BloomFilter {
+	n = max(n, 1)
+	if p <= 0 || p >= 1 {
+		p = 0.01
+	}
+	m := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	m = max(m, 64)
+	k := int(math.Round(float64(m) / float64(n) * math.Ln2))
+	return &BloomFilter{NewBits(int(m)), m, max(k, 1)}
+}
+
+// Add adds the element e to the filter. It returns true if e wasn't already in the filter, and
+// false if it was, or is a false positive.
+func (f *BloomFilter) Add(e int) bool {
+	h1, h2 := bfHashes(e)
+	res := false
+	for i := range f.k {
+		j := int((h1 + uint64(i)*h2) % f.m)
+		if !f.bits.Get(j) {
+			f.bits.Set(j)
+			res = true
+		}
+	}
+	return res
+}
+
+// AddSet adds the elements of s to the filter.
+func (f *BloomFilter) AddSet(s Set) {
+	for e, v := range s {
+		if v {
+			f.Add(e)
+		}
+	}
+}
+
+// Element returns true if the filter possibly contains the element e, and false if it definitely
+// doesn't.
+func (f *BloomFilter) Element(e int) bool {
+	h1, h2 := bfHashes(e)
+	for i := range f.k {
+		if !f.bits.Get(int((h1 + uint64(i)*h2) % f.m)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Merge adds the elements of o to the filter.
+func (f *BloomFilter) Merge(o *BloomFilter) error {
+	if f.m != o.m || f.k != o.k {
+		return ErrSketchMismatch
+	}
+	for i, w := range o.bits {
+		f.bits[i] |= w
+	}
+	return nil
+}
+
+// FalsePositiveRate returns the estimated false positive rate given the number of bits set.
+func (f *BloomFilter) FalsePositiveRate() float64 {
+	set := 0
+	for i := range int(f.m) {
+		if f.bits.Get(i) {
+			set++
+		}
+	}
+	return math.Pow(float64(set)/float64(f.m), float64(f.k))
+}
+
+// Copy makes a copy of the filter.
+func (f *BloomFilter) Copy() *BloomFilter {
+	return &BloomFilter{slices.Clone(f.bits), f.m, f.k}
+}
+
+// bfVersion is the version of the binary encoding.
+const bfVersion = 1
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is a version byte, the number
+// of bits and hashes as uvarints, then the bits as little endian uint64s.
+func (f *BloomFilter) MarshalBinary() ([]byte, error) {
+	res := []byte{bfVersion}
+	res = binary.AppendUvarint(res, f.m)
+	res = binary.AppendUvarint(res, uint64(f.k))
+	for _, w := range f.bits {
+		res = binary.LittleEndian.AppendUint64(res, w)
+	}
+	return res, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (f *BloomFilter) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != bfVersion {
+		return ErrBadEncoding
+	}
+	data = data[1:]
+	m, l := binary.Uvarint(data)
+	if l <= 0 || m == 0 {
+		return ErrBadEncoding
+	}
+	data = data[l:]
+	k, l := binary.Uvarint(data)
+	if l <= 0 || k == 0 || k > 64 {
+		return ErrBadEncoding
+	}
+	data = data[l:]
+	if m > math.MaxInt || m > 64*uint64(len(data)) {
+		return ErrBadEncoding
+	}
+	nw := (m + 63) / 64
+	if uint64(len(data)) != 8*nw {
+		return ErrBadEncoding
+	}
+	bits := make(Bits, nw)
+	for i := range bits {
+		bits[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+	f.bits, f.m, f.k = bits, m, int(k)
+	return nil
+}
+
+// bfHashes returns the two hashes used to derive the k bit positions (Kirsch-Mitzenmacher).
+func bfHashes(e int) (uint64, uint64) {
+	h := mix64(uint64(e))
+	return h, mix64(h) | 1
+}
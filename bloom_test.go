@@ -0,0 +1,26 @@
+package datastruct
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func TestBloomFilterUnmarshalBadLength(t *testing.T) {
+	// A bit count near 2^64 with no data must not pass validation
+	data := []byte{bfVersion}
+	data = binary.AppendUvarint(data, 1<<64-1)
+	data = binary.AppendUvarint(data, 3)
+	var f BloomFilter
+	if err := f.UnmarshalBinary(data); !errors.Is(err, ErrBadEncoding) {
+		t.Fatalf("got %v, want ErrBadEncoding", err)
+	}
+
+	good, _ := NewBloomFilter(100, 0.01).MarshalBinary()
+	if err := f.UnmarshalBinary(good); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.UnmarshalBinary(good[:len(good)-1]); !errors.Is(err, ErrBadEncoding) {
+		t.Fatalf("got %v, want ErrBadEncoding", err)
+	}
+}
@@ -0,0 +1,35 @@
+//go:build ignore
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jphsd/datastruct"
+)
+
+func main() {
+	a := datastruct.NewBitArray(10)
+	a.Set(1)
+	a.Set(9)
+	fmt.Printf("%v Len %d\n", a, a.Len())
+
+	if err := a.Set(10); errors.Is(err, datastruct.ErrOutOfRange) {
+		fmt.Println("Set(10):", err)
+	}
+	if _, err := a.Get(-1); err != nil {
+		fmt.Println("Get(-1):", err)
+	}
+
+	a.Append(true, true, false, true)
+	fmt.Printf("Append %v Len %d\n", a, a.Len())
+	a.Resize(5)
+	fmt.Printf("Resize(5) %v Bits %x\n", a, a.Bits())
+	a.Resize(8)
+	fmt.Printf("Resize(8) %v Slice %v\n", a, a.Slice())
+
+	b, _ := datastruct.BitArrayFromBits(datastruct.Bits{0xff}, 4)
+	fmt.Printf("FromBits(0xff, 4) %v Bits %x\n", b, b.Bits())
+	_, err := datastruct.BitArrayFromBits(datastruct.Bits{0xff}, 65)
+	fmt.Println("FromBits(0xff, 65):", err)
+}
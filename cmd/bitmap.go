@@ -0,0 +1,35 @@
+//go:build ignore
+
+package main
+
+import (
+	"fmt"
+	"github.com/jphsd/datastruct"
+)
+
+func main() {
+	// Sparse ids and a dense range
+	b1 := datastruct.NewBitmap(1, 1000, 1000000, 4000000000)
+	for i := uint32(70000); i < 80000; i++ {
+		b1.Add(i)
+	}
+	b2 := datastruct.NewBitmap(1, 2, 1000000)
+	for i := uint32(75000); i < 90000; i += 2 {
+		b2.Add(i)
+	}
+	fmt.Printf("b1 %d, b2 %d\n", b1.Len(), b2.Len())
+	fmt.Printf("And %d, Or %d, AndNot %d, Xor %d\n", b1.And(b2).Len(), b1.Or(b2).Len(), b1.AndNot(b2).Len(), b1.Xor(b2).Len())
+	fmt.Printf("Rank(75000) %d\n", b1.Rank(75000))
+	v, ok := b1.Select(5000)
+	fmt.Printf("Select(5000) %d %v\n", v, ok)
+
+	data, _ := b1.MarshalBinary()
+	b1.RunOptimize()
+	odata, _ := b1.MarshalBinary()
+	fmt.Printf("Encoded %d bytes, %d after RunOptimize\n", len(data), len(odata))
+	b3 := &datastruct.Bitmap{}
+	if err := b3.UnmarshalBinary(odata); err != nil {
+		fmt.Printf("Unmarshal failed %v\n", err)
+	}
+	fmt.Printf("Decoded %d, Xor with original %d\n", b3.Len(), b3.Xor(b1).Len())
+}
@@ -43,4 +43,21 @@ func main() {
 			fmt.Printf("Attempt to clear bit %d failed\n", ind)
 		}
 	}
+
+	// Bulk operations
+	a, b := datastruct.NewBits(128), datastruct.NewBits(128)
+	a.SetRange(0, 80)
+	b.SetRange(60, 128)
+	fmt.Printf("a      %016x\nb      %016x\n", a, b)
+	fmt.Printf("And    %016x\nOr     %016x\n", a.And(b), a.Or(b))
+	fmt.Printf("Xor    %016x\nAndNot %016x\n", a.Xor(b), a.AndNot(b))
+	fmt.Printf("Not    %016x\n", a.Not())
+	c := datastruct.NewBits(128)
+	c.SetXor(a, b)
+	c.FlipRange(0, 4)
+	c.ClearRange(120, 128)
+	fmt.Printf("SetXor, FlipRange(0, 4), ClearRange(120, 128) %016x\n", c)
+	fmt.Printf("Lsh(8) %016x\nRsh(8) %016x\n", a.Lsh(8), a.Rsh(8))
+	fmt.Printf("a.Equal(a.Lsh(1).Rsh(1)) %v, a.And(b).Rsh(80).None() %v, a.Or(b).All() %v\n",
+		a.Equal(a.Lsh(1).Rsh(1)), a.And(b).Rsh(80).None(), a.Or(b).All())
 }
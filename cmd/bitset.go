@@ -0,0 +1,21 @@
+//go:build ignore
+
+package main
+
+import (
+	"fmt"
+	"github.com/jphsd/datastruct"
+)
+
+func main() {
+	s1 := datastruct.NewBitSet(1, 2, 3, 4, 5, 100)
+	s2 := datastruct.BitSetFromSet(datastruct.NewSet(4, 5, 6, 7, 8))
+	fmt.Printf("%v Union %v = %v\n", s1, s2, s1.Union(s2))
+	fmt.Printf("%v Intersection %v = %v\n", s1, s2, s1.Intersection(s2))
+	fmt.Printf("%v Difference %v = %v\n", s1, s2, s1.Difference(s2))
+	fmt.Printf("%v Sub %v = %v\n", s1, s2, s1.Sub(s2))
+	fmt.Printf("%v Disjoint %v %v\n", s1, s2, s1.Disjoint(s2))
+	s1.Remove(100)
+	s1.Purge()
+	fmt.Printf("Len %d Set %v\n", s1.Len(), s1.ToSet())
+}
@@ -0,0 +1,48 @@
+//go:build ignore
+
+package main
+
+import (
+	"fmt"
+	"github.com/jphsd/datastruct"
+	"slices"
+)
+
+func main() {
+	s := datastruct.NewSet(1, 2, 3, 4)
+	fmt.Printf("PowerSet of %v\n", s)
+	for x := range datastruct.PowerSet(s) {
+		fmt.Printf(" %v", x)
+	}
+	fmt.Println()
+
+	fmt.Printf("Combinations of 2\n")
+	for x := range datastruct.Combinations(s, 2) {
+		fmt.Printf(" %v", x)
+	}
+	fmt.Println()
+
+	fmt.Printf("Product\n")
+	for x := range datastruct.Product(datastruct.NewSet(1, 2), datastruct.NewSet(3), datastruct.NewSet(4, 5)) {
+		fmt.Printf(" %v", x)
+	}
+	fmt.Println()
+
+	fmt.Printf("Partitions of %v\n", datastruct.NewSet(1, 2, 3))
+	for p := range datastruct.Partitions(datastruct.NewSet(1, 2, 3)) {
+		fmt.Printf(" %v", p)
+	}
+	fmt.Println()
+
+	// Mask mode, elements in a fixed order
+	features := []string{"age", "income", "region", "tenure", "plan"}
+	fmt.Printf("CombinationMasks(5, 3)\n")
+	for m := range datastruct.CombinationMasks(len(features), 3) {
+		fmt.Printf(" %05b %v\n", m, slices.Collect(datastruct.MaskElements(features, m)))
+	}
+	n := 0
+	for range datastruct.PowerSetMasks(20) {
+		n++
+	}
+	fmt.Printf("PowerSetMasks(20) yields %d masks\n", n)
+}
@@ -0,0 +1,54 @@
+//go:build ignore
+
+package main
+
+// Demonstrates concurrent use. The race detector is exercised by TestConcurrentSet under
+// go test -race.
+
+import (
+	"fmt"
+	"github.com/jphsd/datastruct"
+	"sync"
+	"sync/atomic"
+)
+
+func main() {
+	s := datastruct.NewConcurrentSet[int]()
+	var added atomic.Int64
+	var wg sync.WaitGroup
+	// Several goroutines add overlapping ranges, and read, at the same time
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 10000; i++ {
+				if s.AddIfAbsent(g*5000 + i) {
+					added.Add(1)
+				}
+				if i%1000 == 0 {
+					_ = s.Len()
+					_ = s.Slice()
+				}
+				s.Element(i)
+			}
+		}(g)
+	}
+	// And another removes
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			s.Remove(i)
+		}
+	}()
+	wg.Wait()
+
+	fmt.Printf("Added %d, Len %d\n", added.Load(), s.Len())
+	odd := datastruct.NewSet()
+	for i := 1; i < 2000; i += 2 {
+		odd.Add(i)
+	}
+	fmt.Printf("Intersection with odd numbers below 2000 %d\n", s.Intersection(odd).Len())
+	s.SubWith(odd)
+	fmt.Printf("After SubWith, Disjoint %v\n", s.Disjoint(odd))
+}
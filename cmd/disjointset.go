@@ -0,0 +1,59 @@
+//go:build ignore
+
+package main
+
+import (
+	"fmt"
+	"github.com/jphsd/datastruct"
+	"math/rand"
+	"sort"
+)
+
+func main() {
+	// Label boxes that share a grid cell as connected
+	n := 20
+	g := datastruct.NewBBGrid(10, 10, [][]float64{{0, 0}, {100, 100}})
+	for i := 0; i < n; i++ {
+		x, y := rand.Float64()*95, rand.Float64()*95
+		g.Add(i, [][]float64{{x, y}, {x + 5, y + 5}})
+	}
+	ds := datastruct.NewDisjointSet(n)
+	for r := 0; r < g.Rows; r++ {
+		for c := 0; c < g.Columns; c++ {
+			ids := g.Cell(r, c)
+			for i := 1; i < len(ids); i++ {
+				ds.Union(ids[0], ids[i])
+			}
+		}
+	}
+	fmt.Printf("%d boxes in %d components\n", ds.Len(), ds.Count())
+	for _, comp := range ds.Components() {
+		fmt.Printf("%v ", comp)
+	}
+	fmt.Println()
+
+	// Kruskal MST over random points
+	pts := make([][]float64, 8)
+	for i := range pts {
+		pts[i] = []float64{rand.Float64(), rand.Float64()}
+	}
+	type edge struct {
+		a, b int
+		d    float64
+	}
+	edges := []edge{}
+	for i := range pts {
+		for j := i + 1; j < len(pts); j++ {
+			dx, dy := pts[i][0]-pts[j][0], pts[i][1]-pts[j][1]
+			edges = append(edges, edge{i, j, dx*dx + dy*dy})
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool { return edges[i].d < edges[j].d })
+	mst := datastruct.NewDisjointSet(len(pts))
+	for _, e := range edges {
+		if mst.Union(e.a, e.b) {
+			fmt.Printf("%d-%d ", e.a, e.b)
+		}
+	}
+	fmt.Printf("\nComponents %d\n", mst.Count())
+}
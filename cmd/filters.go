@@ -0,0 +1,49 @@
+//go:build ignore
+
+package main
+
+import (
+	"fmt"
+	"github.com/jphsd/datastruct"
+)
+
+func main() {
+	const n = 100000
+
+	// Two shards of a Bloom filter, merged
+	b1, b2 := datastruct.NewBloomFilter(n, 0.01), datastruct.NewBloomFilter(n, 0.01)
+	for i := range n / 2 {
+		b1.Add(2 * i)
+		b2.Add(2*i + n)
+	}
+	b1.Merge(b2)
+	data, _ := b1.MarshalBinary()
+	var b datastruct.BloomFilter
+	b.UnmarshalBinary(data)
+	fmt.Printf("Bloom %d bytes, Element(0) %v Element(%d) %v\n", len(data), b.Element(0), n, b.Element(n))
+	fp := 0
+	for i := range n {
+		if b.Element(-i - 1) {
+			fp++
+		}
+	}
+	fmt.Printf("Bloom false positives %.4f%% estimated %.4f%%\n", 100*float64(fp)/n, 100*b.FalsePositiveRate())
+
+	c := datastruct.NewCuckooFilter(n)
+	for i := range n {
+		c.Add(i)
+	}
+	data, _ = c.MarshalBinary()
+	fmt.Printf("Cuckoo %d bytes, Len %d\n", len(data), c.Len())
+	for i := range n / 2 {
+		c.Remove(i)
+	}
+	fmt.Printf("Removed half, Len %d Element(0) %v Element(%d) %v\n", c.Len(), c.Element(0), n-1, c.Element(n-1))
+	fp = 0
+	for i := range n {
+		if c.Element(-i - 1) {
+			fp++
+		}
+	}
+	fmt.Printf("Cuckoo false positives %.4f%%\n", 100*float64(fp)/n)
+}
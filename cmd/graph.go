@@ -0,0 +1,44 @@
+//go:build ignore
+
+package main
+
+import (
+	"fmt"
+	"github.com/jphsd/datastruct/graph"
+	"math"
+)
+
+const (
+	w, h = 20, 10
+)
+
+// Wall down the middle with a gap at the bottom
+func open(x, y int) bool {
+	return x != w/2 || y == h-1
+}
+
+func main() {
+	g := graph.GraphFunc(func(id int, f func(int, float64)) {
+		x, y := id%w, id/w
+		for _, d := range [][]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+			nx, ny := x+d[0], y+d[1]
+			if nx < 0 || nx >= w || ny < 0 || ny >= h || !open(nx, ny) {
+				continue
+			}
+			f(ny*w+nx, 1)
+		}
+	})
+	start, goal := 0, w-1
+	manhattan := func(id int) float64 {
+		return math.Abs(float64(id%w-goal%w)) + math.Abs(float64(id/w-goal/w))
+	}
+
+	path, cost, err := graph.Dijkstra(g, start, goal)
+	fmt.Printf("Dijkstra %v %f %v\n", path, cost, err)
+	path, cost, err = graph.AStar(g, manhattan, start, goal)
+	fmt.Printf("A* %v %f %v\n", path, cost, err)
+	path, cost, err = graph.Bidirectional(g, g, start, goal)
+	fmt.Printf("Bidirectional %v %f %v\n", path, cost, err)
+	path, cost, err = graph.Dijkstra(g, start, w/2)
+	fmt.Printf("Unreachable %v %f %v\n", path, cost, err)
+}
@@ -0,0 +1,25 @@
+//go:build ignore
+
+package main
+
+import (
+	"fmt"
+	"github.com/jphsd/datastruct"
+)
+
+func main() {
+	s := datastruct.NewHashSet(1, 2, 3, 4, 5)
+	s.Remove(3)
+	fmt.Printf("%v Len %d\n", s, s.Len())
+
+	// Migrating from Set, and back for the package level set functions
+	t := datastruct.HashSetFromSet(datastruct.NewSet(1, 2, 3))
+	t.Remove(2)
+	fmt.Printf("%v Len %d\n", t, t.Len())
+	fmt.Printf("UnionAll %v\n", datastruct.UnionAll(datastruct.HashSetToSet(s), datastruct.HashSetToSet(t), datastruct.NewSet(9)))
+
+	u := s.Union(t)
+	fmt.Printf("Union %v Len %d\n", u, u.Len())
+	u.SubWith(s)
+	fmt.Printf("SubWith %v Empty %v\n", u, u.Empty())
+}
@@ -0,0 +1,44 @@
+//go:build ignore
+
+package main
+
+import (
+	"fmt"
+	"github.com/jphsd/datastruct"
+)
+
+func main() {
+	a, b := datastruct.NewPairingHeap(), datastruct.NewPairingHeap()
+	for i := 0; i < 5; i++ {
+		a.Insert(i, float64(10-i))
+		b.Insert(i+10, float64(i*3))
+	}
+	a.Insert(0, 1) // Decrease key
+	if err := a.Meld(b); err != nil {
+		fmt.Printf("Meld failed %v\n", err)
+	}
+	fmt.Printf("Pairing heap %d entries, b %d\n", a.Len(), b.Len())
+	for a.Len() > 0 {
+		id, _ := a.Pop()
+		fmt.Printf("%d ", id)
+	}
+	fmt.Println()
+
+	c, d := datastruct.NewFibHeap(), datastruct.NewFibHeap()
+	for i := 0; i < 5; i++ {
+		c.Insert(i, float64(10-i))
+		d.Insert(i+10, float64(i*3))
+	}
+	c.Insert(4, 20) // Increase key
+	if err := c.Meld(d); err != nil {
+		fmt.Printf("Meld failed %v\n", err)
+	}
+	d.Insert(1, 0)
+	fmt.Printf("Meld with duplicate id %v\n", c.Meld(d))
+	fmt.Printf("Fibonacci heap %d entries, d %d\n", c.Len(), d.Len())
+	for c.Len() > 0 {
+		id, _ := c.Pop()
+		fmt.Printf("%d ", id)
+	}
+	fmt.Println()
+}
@@ -48,7 +48,7 @@ func nearest(pt []float64, pts [][]float64) [][]float64 {
 	}
 	pq := datastruct.NewPriorityList(pis...)
 	res := make([][]float64, n)
-	for i, pi := range *pq {
+	for i, pi := range pq.Slice() {
 		res[i] = pts[pi.Id]
 	}
 	return res
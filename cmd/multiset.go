@@ -0,0 +1,27 @@
+//go:build ignore
+
+package main
+
+import (
+	"fmt"
+	"github.com/jphsd/datastruct"
+)
+
+func main() {
+	a := datastruct.NewMultiset("go", "go", "rust", "c", "go", "c")
+	b := datastruct.NewMultiset("go", "c", "c", "c", "zig")
+	fmt.Printf("a %v Len %d Distinct %d\n", a, a.Len(), a.Distinct())
+	fmt.Printf("b %v\n", b)
+	fmt.Printf("Union %v\n", a.Union(b))
+	fmt.Printf("Sum %v\n", a.Sum(b))
+	fmt.Printf("Intersection %v\n", a.Intersection(b))
+	fmt.Printf("Sub %v\n", a.Sub(b))
+	fmt.Printf("Difference %v\n", a.Difference(b))
+	fmt.Printf("MostCommon(2) %v\n", a.Sum(b).MostCommon(2))
+
+	a.Add("zig", 3)
+	a.Remove("go", 2)
+	fmt.Printf("Add zig 3, Remove go 2 %v Count(go) %d\n", a, a.Count("go"))
+	fmt.Printf("ToSet %v\n", a.ToSet())
+	fmt.Printf("FromSet %v\n", datastruct.MultisetFromSet(datastruct.NewSet(1, 2, 3)))
+}
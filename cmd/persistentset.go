@@ -0,0 +1,32 @@
+//go:build ignore
+
+package main
+
+import (
+	"fmt"
+	"github.com/jphsd/datastruct"
+)
+
+func main() {
+	// Keep every version of the set on an undo stack
+	versions := []datastruct.PersistentSet[int]{datastruct.NewPersistentSet(1, 2, 3)}
+	cur := versions[0]
+	for _, e := range []int{4, 5, 6} {
+		cur = cur.Add(e)
+		versions = append(versions, cur)
+	}
+	cur = cur.Remove(1)
+	versions = append(versions, cur)
+	for i, v := range versions {
+		fmt.Printf("Version %d Len %d %v\n", i, v.Len(), v)
+	}
+
+	// Undo the removal
+	cur = versions[len(versions)-2]
+	fmt.Printf("Undo %v Equal to version 3 %v, version 4 %v\n", cur, cur.Equal(versions[3]), cur.Equal(versions[4]))
+
+	other := datastruct.NewPersistentSet(6, 5, 4, 3, 2, 1)
+	fmt.Printf("Built in another order Equal %v\n", other.Equal(cur))
+	evens := datastruct.NewPersistentSet(2, 4, 6, 8)
+	fmt.Printf("Intersection %v Sub %v Difference %v\n", cur.Intersection(evens), cur.Sub(evens), cur.Difference(evens))
+}
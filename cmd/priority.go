@@ -40,7 +40,7 @@ func main() {
 		fmt.Printf("%d: Pri %f Id %d\n", i, itm.Priority, itm.Id)
 	}
 
-	pi := (*l)[4]
+	pi := l.Slice()[4]
 	pi.Priority -= 1
 	l.ChangedPriority(pi)
 	for i, itm := range l.Slice() {
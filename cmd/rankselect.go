@@ -0,0 +1,55 @@
+//go:build ignore
+
+package main
+
+import (
+	"fmt"
+	"github.com/jphsd/datastruct"
+	"math/rand"
+	"time"
+)
+
+func main() {
+	b := datastruct.NewBits(200)
+	for _, i := range []int{3, 5, 64, 65, 130, 199} {
+		b.Set(i)
+	}
+	fmt.Printf("Count %d NextSet(6) %d NextClear(64) %d\n", b.Count(), b.NextSet(6), b.NextClear(64))
+	fmt.Print("Ones")
+	for i := range b.Ones() {
+		fmt.Printf(" %d", i)
+	}
+	fmt.Println()
+
+	rs := datastruct.NewRankSelect(b)
+	for _, i := range []int{0, 4, 64, 66, 200} {
+		fmt.Printf("Rank(%d) %d ", i, rs.Rank(i))
+	}
+	fmt.Println()
+	for k := range rs.Count() + 1 {
+		fmt.Printf("Select(%d) %d ", k, rs.Select(k))
+	}
+	fmt.Println()
+
+	// Timing on a large sparse array
+	n := 1 << 26
+	b = datastruct.NewBits(n)
+	for range n / 16 {
+		b.Set(rand.Intn(n))
+	}
+	start := time.Now()
+	rs = datastruct.NewRankSelect(b)
+	fmt.Printf("Index %d bits, %d set, in %v\n", n, rs.Count(), time.Since(start))
+	const m = 1000000
+	start = time.Now()
+	sum := 0
+	for range m {
+		sum += rs.Rank(rand.Intn(n))
+	}
+	fmt.Printf("Rank %v/op\n", time.Since(start)/m)
+	start = time.Now()
+	for range m {
+		sum += rs.Select(rand.Intn(rs.Count()))
+	}
+	fmt.Printf("Select %v/op\n", time.Since(start)/m)
+}
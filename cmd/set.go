@@ -3,6 +3,7 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/jphsd/datastruct"
 )
@@ -73,4 +74,28 @@ func main() {
 	fmt.Printf("%v %v Disjoint %v\n", ok, s1, s3)
 
 	fmt.Printf("Slice %v %v\n", s1, s1.Slice())
+
+	s3 = datastruct.UnionAll(s1, s2, datastruct.NewSet(20, 21))
+	fmt.Printf("UnionAll %v\n", s3)
+	s3 = datastruct.IntersectAll(s1, s2, datastruct.NewSet(4, 20))
+	fmt.Printf("IntersectAll %v\n", s3)
+	s3 = s1.Copy()
+	s3.SubWith(s2)
+	fmt.Printf("%v SubWith %v = %v\n", s1, s2, s3)
+
+	t1 := datastruct.NewSetOf("red", "green", "blue")
+	t2 := datastruct.NewSetOf("blue", "yellow")
+	fmt.Printf("%v Union %v = %v\n", t1, t2, t1.Union(t2))
+	fmt.Printf("%v Intersection %v = %v\n", t1, t2, datastruct.Intersection(t1, t2))
+	fmt.Printf("%v Sub %v = %v\n", t1, t2, t1.Sub(t2))
+
+	data, _ := json.Marshal(s1)
+	var s4 datastruct.Set
+	json.Unmarshal(data, &s4)
+	fmt.Printf("JSON %s -> %v\n", data, s4)
+	s4.UnmarshalText([]byte("{10, 20, 30}"))
+	fmt.Printf("Text {10, 20, 30} -> %v\n", s4)
+	data, _ = s4.MarshalBinary()
+	s4.UnmarshalBinary(data)
+	fmt.Printf("Binary % x -> %v\n", data, s4)
 }
@@ -0,0 +1,55 @@
+//go:build ignore
+
+package main
+
+import (
+	"fmt"
+	"github.com/jphsd/datastruct"
+)
+
+func main() {
+	// Two shards each of two sets
+	a1, a2, b1, b2 := datastruct.NewSet(), datastruct.NewSet(), datastruct.NewSet(), datastruct.NewSet()
+	for i := range 50000 {
+		a1.Add(i)
+		a2.Add(i + 50000)
+		b1.Add(i + 60000)
+		b2.Add(i + 110000)
+	}
+	a, b := a1.Union(a2), b1.Union(b2)
+	fmt.Printf("Exact Jaccard %.4f Union %d\n", datastruct.Jaccard(a, b), len(a.Union(b)))
+
+	// Sketch each shard separately, then merge
+	ma, mb := datastruct.NewMinHash(256, 1), datastruct.NewMinHash(256, 1)
+	ha, hb := datastruct.NewHyperLogLog(12), datastruct.NewHyperLogLog(12)
+	for _, s := range []datastruct.Set{a1, a2} {
+		m, h := datastruct.NewMinHash(256, 1), datastruct.NewHyperLogLog(12)
+		m.AddSet(s)
+		h.AddSet(s)
+		ma.Merge(m)
+		ha.Merge(h)
+	}
+	for _, s := range []datastruct.Set{b1, b2} {
+		m, h := datastruct.NewMinHash(256, 1), datastruct.NewHyperLogLog(12)
+		m.AddSet(s)
+		h.AddSet(s)
+		mb.Merge(m)
+		hb.Merge(h)
+	}
+
+	// Round trip through the binary encoding
+	data, _ := mb.MarshalBinary()
+	var mc datastruct.MinHash
+	mc.UnmarshalBinary(data)
+	sim, _ := ma.Similarity(&mc)
+	fmt.Printf("MinHash estimate %.4f (%d bytes)\n", sim, len(data))
+
+	data, _ = hb.MarshalBinary()
+	var hc datastruct.HyperLogLog
+	hc.UnmarshalBinary(data)
+	ha.Merge(&hc)
+	fmt.Printf("HyperLogLog union estimate %d (%d bytes)\n", ha.Count(), len(data))
+
+	_, err := ma.Similarity(datastruct.NewMinHash(128, 1))
+	fmt.Println("Mismatched sketches:", err)
+}
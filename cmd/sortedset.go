@@ -0,0 +1,32 @@
+//go:build ignore
+
+package main
+
+import (
+	"fmt"
+	"github.com/jphsd/datastruct"
+)
+
+func main() {
+	s := datastruct.NewSet(9, 3, 7, 1, 5)
+	fmt.Printf("Set %v Sorted %v\n", s, s.Sorted())
+	min, _ := s.Min()
+	max, _ := s.Max()
+	fmt.Printf("Min %d Max %d Between(2, 7) %v\n", min, max, s.Between(2, 7))
+	for e := range s.All() {
+		fmt.Printf("%d ", e)
+	}
+	fmt.Println()
+
+	ss := datastruct.NewSortedSet("pear", "apple", "fig", "kiwi", "banana")
+	fmt.Printf("SortedSet %v\n", ss)
+	for e := range ss.Range("b", "g") {
+		fmt.Printf("%s ", e)
+	}
+	fmt.Println()
+	c, _ := ss.Ceiling("c")
+	f, _ := ss.Floor("c")
+	fmt.Printf("Ceiling(c) %s Floor(c) %s\n", c, f)
+	ss.Remove("fig")
+	fmt.Printf("Remove fig %v Len %d\n", ss, ss.Len())
+}
@@ -0,0 +1,34 @@
+//go:build ignore
+
+package main
+
+import (
+	"fmt"
+	"github.com/jphsd/datastruct"
+	"math/rand"
+	"sync"
+)
+
+func main() {
+	// Each goroutine finds the top 5 of its share of the items, then the results are merged
+	n, k := 4, 5
+	parts := make([]*datastruct.TopK, n)
+	var wg sync.WaitGroup
+	for p := 0; p < n; p++ {
+		parts[p] = datastruct.NewTopK(k, true)
+		wg.Add(1)
+		go func(tk *datastruct.TopK, base int) {
+			defer wg.Done()
+			for i := 0; i < 100000; i++ {
+				tk.Add(base+i, rand.Float64())
+			}
+		}(parts[p], p*100000)
+	}
+	wg.Wait()
+
+	res := datastruct.NewTopK(k, true)
+	res.Merge(parts...)
+	for i, itm := range res.Sorted() {
+		fmt.Printf("%d: Pri %f Id %d\n", i, itm.Priority, itm.Id)
+	}
+}
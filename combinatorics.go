@@ -0,0 +1,198 @@
+package datastruct
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// PowerSet returns an iterator over all the subsets of s, smallest first, starting with the empty
+// set and ending with a copy of s. There are 2^n of them, so s should be small.
+func PowerSet[S ~map[T]bool, T comparable](s S) iter.Seq[S] {
+	return func(yield func(S) bool) {
+		elts := setSlice(s)
+		for k := 0; k <= len(elts); k++ {
+			if !combinations(elts, k, yield) {
+				return
+			}
+		}
+	}
+}
+
+// Combinations returns an iterator over all the subsets of s with k elements.
+func Combinations[S ~map[T]bool, T comparable](s S, k int) iter.Seq[S] {
+	return func(yield func(S) bool) {
+		combinations(setSlice(s), k, yield)
+	}
+}
+
+// combinations yields the k element subsets of elts in lexicographic order of their indices.
+// It returns false if the iteration was stopped.
+func combinations[S ~map[T]bool, T comparable](elts []T, k int, yield func(S) bool) bool {
+	n := len(elts)
+	if k < 0 || k > n {
+		return true
+	}
+	idx := make([]int, k)
+	for i := range idx {
+		idx[i] = i
+	}
+	for {
+		res := make(S, k)
+		for _, i := range idx {
+			res[elts[i]] = true
+		}
+		if !yield(res) {
+			return false
+		}
+		// Find the rightmost index that can be advanced
+		i := k - 1
+		for i >= 0 && idx[i] == n-k+i {
+			i--
+		}
+		if i < 0 {
+			return true
+		}
+		idx[i]++
+		for j := i + 1; j < k; j++ {
+			idx[j] = idx[j-1] + 1
+		}
+	}
+}
+
+// Product returns an iterator over the Cartesian product of the sets, as slices with one element
+// from each set in turn. The product of no sets is a single empty slice.
+func Product[S ~map[T]bool, T comparable](sets ...S) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		elts := make([][]T, len(sets))
+		for i, s := range sets {
+			elts[i] = setSlice(s)
+			if len(elts[i]) == 0 {
+				return
+			}
+		}
+		idx := make([]int, len(sets))
+		for {
+			res := make([]T, len(sets))
+			for i, j := range idx {
+				res[i] = elts[i][j]
+			}
+			if !yield(res) {
+				return
+			}
+			// Odometer, last set changes fastest
+			i := len(idx) - 1
+			for ; i >= 0; i-- {
+				idx[i]++
+				if idx[i] < len(elts[i]) {
+					break
+				}
+				idx[i] = 0
+			}
+			if i < 0 {
+				return
+			}
+		}
+	}
+}
+
+// Partitions returns an iterator over all the partitions of s, ways of dividing s into non-empty
+// disjoint subsets whose union is s. The number of partitions grows faster than 2^n (the Bell
+// numbers), so s should be small. The empty set has one partition, with no subsets.
+func Partitions[S ~map[T]bool, T comparable](s S) iter.Seq[[]S] {
+	return func(yield func([]S) bool) {
+		elts := setSlice(s)
+		n := len(elts)
+		// Restricted growth strings, a[i] is the subset elts[i] is in and is at most one more than
+		// the largest of a[0:i]. m[i] is the largest of a[0:i+1].
+		a, m := make([]int, n), make([]int, n)
+		for {
+			nparts := 0
+			if n > 0 {
+				nparts = m[n-1] + 1
+			}
+			res := make([]S, nparts)
+			for i := range res {
+				res[i] = make(S)
+			}
+			for i, e := range elts {
+				res[a[i]][e] = true
+			}
+			if !yield(res) {
+				return
+			}
+			i := n - 1
+			for i > 0 && a[i] > m[i-1] {
+				i--
+			}
+			if i <= 0 {
+				return
+			}
+			a[i]++
+			m[i] = max(m[i-1], a[i])
+			for j := i + 1; j < n; j++ {
+				a[j], m[j] = 0, m[i]
+			}
+		}
+	}
+}
+
+// PowerSetMasks returns an iterator over the 2^n subsets of a universe of n elements, n at most
+// 64, as masks in increasing order. Bit i of a mask is set if element i is in the subset. Use
+// MaskElements to map a mask back to elements, or Bits{mask} to treat it as Bits.
+func PowerSetMasks(n int) iter.Seq[uint64] {
+	if n < 0 || n > 64 {
+		panic("datastruct: PowerSetMasks universe must be 0 to 64 elements")
+	}
+	return func(yield func(uint64) bool) {
+		last := ^uint64(0) >> (64 - n)
+		if n == 0 {
+			last = 0
+		}
+		for m := uint64(0); ; m++ {
+			if !yield(m) || m == last {
+				return
+			}
+		}
+	}
+}
+
+// CombinationMasks returns an iterator over the subsets with k elements of a universe of n
+// elements, n at most 64, as masks in increasing order. It uses Gosper's hack to step from one
+// mask to the next in O(1).
+func CombinationMasks(n, k int) iter.Seq[uint64] {
+	if n < 0 || n > 64 {
+		panic("datastruct: CombinationMasks universe must be 0 to 64 elements")
+	}
+	return func(yield func(uint64) bool) {
+		if k < 0 || k > n {
+			return
+		}
+		if k == 0 {
+			yield(0)
+			return
+		}
+		m := ^uint64(0) >> (64 - k)
+		last := m << (n - k)
+		for {
+			if !yield(m) || m == last {
+				return
+			}
+			c := m & -m
+			r := m + c
+			m = (((r ^ m) >> 2) / c) | r
+		}
+	}
+}
+
+// MaskElements returns an iterator over the elements of elts selected by the set bits of mask.
+func MaskElements[T any](elts []T, mask uint64) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for mask != 0 {
+			i := bits.TrailingZeros64(mask)
+			if i >= len(elts) || !yield(elts[i]) {
+				return
+			}
+			mask &= mask - 1
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package datastruct
+
+import (
+	"hash/maphash"
+	"sync"
+)
+
+// csShards is the number of independently locked shards in a ConcurrentSet.
+const csShards = 32
+
+// ConcurrentSet represents a set of comparable elements that is safe for concurrent use. Elements
+// are spread over a number of shards, each with its own lock, so goroutines working on different
+// elements rarely contend. Operations over the whole set, such as Len and Slice, lock every shard
+// and so see a consistent snapshot. Use NewConcurrentSet to create one.
+type ConcurrentSet[T comparable] struct {
+	seed   maphash.Seed
+	shards [csShards]csShard[T]
+}
+
+type csShard[T comparable] struct {
+	sync.RWMutex
+	m map[T]bool
+}
+
+// NewConcurrentSet returns a new set with the provided elements in it.
+func NewConcurrentSet[T comparable](elts ...T) *ConcurrentSet[T] {
+	res := &ConcurrentSet[T]{seed: maphash.MakeSeed()}
+	for i := range res.shards {
+		res.shards[i].m = make(map[T]bool)
+	}
+	for _, e := range elts {
+		res.AddIfAbsent(e)
+	}
+	return res
+}
+
+// AddIfAbsent adds the element e to the set and returns true if it wasn't already in the set.
+// Only one of several goroutines adding the same element will see true.
+func (s *ConcurrentSet[T]) AddIfAbsent(e T) bool {
+	sh := s.shard(e)
+	sh.Lock()
+	defer sh.Unlock()
+	if sh.m[e] {
+		return false
+	}
+	sh.m[e] = true
+	return true
+}
+
+// Add is the same as AddIfAbsent and is provided for parity with Set.
+func (s *ConcurrentSet[T]) Add(e T) bool {
+	return s.AddIfAbsent(e)
+}
+
+// Remove removes element e from the set and returns true if it was in the set.
+// Removing a non-existent element is a no-op signified by false.
+func (s *ConcurrentSet[T]) Remove(e T) bool {
+	sh := s.shard(e)
+	sh.Lock()
+	defer sh.Unlock()
+	if !sh.m[e] {
+		return false
+	}
+	delete(sh.m, e)
+	return true
+}
+
+// Element returns true if the set contains the element e.
+func (s *ConcurrentSet[T]) Element(e T) bool {
+	sh := s.shard(e)
+	sh.RLock()
+	defer sh.RUnlock()
+	return sh.m[e]
+}
+
+// Empty returns true if the set is the empty set.
+func (s *ConcurrentSet[T]) Empty() bool {
+	return s.Len() == 0
+}
+
+// Len returns the number of elements in the set.
+func (s *ConcurrentSet[T]) Len() int {
+	s.rlockAll()
+	defer s.runlockAll()
+	n := 0
+	for i := range s.shards {
+		n += len(s.shards[i].m)
+	}
+	return n
+}
+
+// Snapshot returns a copy of the set's contents at a single point in time.
+func (s *ConcurrentSet[T]) Snapshot() SetOf[T] {
+	s.rlockAll()
+	defer s.runlockAll()
+	n := 0
+	for i := range s.shards {
+		n += len(s.shards[i].m)
+	}
+	res := make(SetOf[T], n)
+	for i := range s.shards {
+		for e := range s.shards[i].m {
+			res[e] = true
+		}
+	}
+	return res
+}
+
+// Slice returns an unsorted slice representation of the set at a single point in time.
+func (s *ConcurrentSet[T]) Slice() []T {
+	return s.Snapshot().Slice()
+}
+
+// String returns a string representation of the set.
+func (s *ConcurrentSet[T]) String() string {
+	return s.Snapshot().String()
+}
+
+// The set operations work on a snapshot of the set. The argument can be a Set or SetOf, but b
+// mustn't be modified concurrently.
+
+// Union returns a new set containing the union of the set and b (OR).
+func (s *ConcurrentSet[T]) Union(b map[T]bool) SetOf[T] {
+	return Union(s.Snapshot(), b)
+}
+
+// Intersection returns a new set containing the intersection of the set and b (AND).
+func (s *ConcurrentSet[T]) Intersection(b map[T]bool) SetOf[T] {
+	return Intersection(s.Snapshot(), b)
+}
+
+// Difference returns a new set containing only the elements in either the set or b but not in both (XOR).
+func (s *ConcurrentSet[T]) Difference(b map[T]bool) SetOf[T] {
+	return Difference(s.Snapshot(), b)
+}
+
+// Sub returns a new set containing the elements in the set which are not in b (SUB).
+func (s *ConcurrentSet[T]) Sub(b map[T]bool) SetOf[T] {
+	return Sub(s.Snapshot(), b)
+}
+
+// Contains returns true if b is completely contained in the set.
+func (s *ConcurrentSet[T]) Contains(b map[T]bool) bool {
+	return Contains(s.Snapshot(), b)
+}
+
+// Disjoint returns true if the set and b share no elements in common.
+func (s *ConcurrentSet[T]) Disjoint(b map[T]bool) bool {
+	return Disjoint(s.Snapshot(), b)
+}
+
+// UnionWith adds the elements of b to the set (OR). Each element is added atomically, but not the
+// elements as a whole.
+func (s *ConcurrentSet[T]) UnionWith(b map[T]bool) {
+	for e, v := range b {
+		if v {
+			s.AddIfAbsent(e)
+		}
+	}
+}
+
+// SubWith removes the elements in b from the set (SUB). Each element is removed atomically, but
+// not the elements as a whole.
+func (s *ConcurrentSet[T]) SubWith(b map[T]bool) {
+	for e, v := range b {
+		if v {
+			s.Remove(e)
+		}
+	}
+}
+
+func (s *ConcurrentSet[T]) shard(e T) *csShard[T] {
+	return &s.shards[maphash.Comparable(s.seed, e)%csShards]
+}
+
+// rlockAll read locks all the shards, always in the same order.
+func (s *ConcurrentSet[T]) rlockAll() {
+	for i := range s.shards {
+		s.shards[i].RLock()
+	}
+}
+
+func (s *ConcurrentSet[T]) runlockAll() {
+	for i := range s.shards {
+		s.shards[i].RUnlock()
+	}
+}
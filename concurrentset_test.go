@@ -0,0 +1,72 @@
+package datastruct
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// Run with go test -race to check for data races.
+func TestConcurrentSet(t *testing.T) {
+	const (
+		n       = 10000
+		writers = 8
+	)
+	s := NewConcurrentSet[int]()
+	var wins [n]atomic.Int32
+	var wg sync.WaitGroup
+
+	// Every writer tries to add every element in [0, n), exactly one must win each
+	for g := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range n {
+				e := (i + g*n/writers) % n
+				if s.AddIfAbsent(e) {
+					wins[e].Add(1)
+				}
+			}
+		}()
+	}
+
+	// Meanwhile add and remove elements in [n, 2n), and read the set
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := n; i < 2*n; i++ {
+			s.Add(i)
+			if !s.Remove(i) {
+				t.Errorf("Remove(%d) = false after Add", i)
+			}
+		}
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			l := s.Len()
+			if l < 0 || l > 2*n {
+				t.Errorf("Len() = %d", l)
+			}
+			for _, e := range s.Slice() {
+				if e < 0 || e >= 2*n {
+					t.Errorf("Slice() contains %d", e)
+				}
+			}
+		}
+	}()
+	wg.Wait()
+
+	for e := range n {
+		if w := wins[e].Load(); w != 1 {
+			t.Fatalf("AddIfAbsent(%d) won %d times, want 1", e, w)
+		}
+	}
+	if l := s.Len(); l != n {
+		t.Fatalf("Len() = %d, want %d", l, n)
+	}
+	if l := len(s.Slice()); l != n {
+		t.Fatalf("len(Slice()) = %d, want %d", l, n)
+	}
+}
@@ -0,0 +1,215 @@
+package datastruct
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"math/rand/v2"
+	"slices"
+)
+
+// CuckooFilter is a probabilistic set of integers which, unlike BloomFilter, supports removal.
+// Each element is stored as a 16 bit fingerprint in one of two candidate buckets of four slots,
+// giving a false positive rate of about 0.01%. Removing an element that was never added may
+// remove another element with the same fingerprint. Adding the same element more than once
+// stores it more than once, so it must be removed as many times.
+type CuckooFilter struct {
+	buckets [][cfSlots]uint16
+	mask    uint64
+	n       int
+	victim  uint16 // fingerprint evicted from a full filter, or 0
+	vindex  uint64
+}
+
+const (
+	cfSlots    = 4
+	cfMaxKicks = 500
+)
+
+// NewCuckooFilter creates a new filter with room for at least n elements.
+func NewCuckooFilter(n int) *CuckooFilter {
+	// Aim for a load factor of 95%
+	nb := uint64(max(n, 1)*100/95+cfSlots-1) / cfSlots
+	nb = 1 << bits.Len64(nb-1)
+	return &CuckooFilter{buckets: make([][cfSlots]uint16, nb), mask: nb - 1}
+}
+
+// Add adds the element e to the filter. It returns false if the filter is full, in which case e
+// hasn't been added.
+func (f *CuckooFilter) Add(e int) bool {
+	if f.victim != 0 {
+		return false
+	}
+	fp, i1, i2 := f.hashes(e)
+	if f.insert(fp, i1) || f.insert(fp, i2) {
+		f.n++
+		return true
+	}
+	// Kick existing fingerprints to their alternate buckets
+	i := i1
+	if rand.IntN(2) == 0 {
+		i = i2
+	}
+	for range cfMaxKicks {
+		j := rand.IntN(cfSlots)
+		fp, f.buckets[i][j] = f.buckets[i][j], fp
+		i = f.alt(fp, i)
+		if f.insert(fp, i) {
+			f.n++
+			return true
+		}
+	}
+	// Keep the last evicted fingerprint so nothing is lost, e is in the filter
+	f.victim, f.vindex = fp, i
+	f.n++
+	return true
+}
+
+// Element returns true if the filter possibly contains the element e, and false if it definitely
+// doesn't.
+func (f *CuckooFilter) Element(e int) bool {
+	fp, i1, i2 := f.hashes(e)
+	if f.victim == fp && (f.vindex == i1 || f.vindex == i2) {
+		return true
+	}
+	return slices.Contains(f.buckets[i1][:], fp) || slices.Contains(f.buckets[i2][:], fp)
+}
+
+// Remove removes the element e from the filter and returns true. If e isn't in the filter then
+// false is returned.
+func (f *CuckooFilter) Remove(e int) bool {
+	fp, i1, i2 := f.hashes(e)
+	if f.victim == fp && (f.vindex == i1 || f.vindex == i2) {
+		f.victim = 0
+		f.n--
+		return true
+	}
+	if !f.delete(fp, i1) && !f.delete(fp, i2) {
+		return false
+	}
+	f.n--
+	if f.victim != 0 {
+		// There's room for it now
+		fp := f.victim
+		f.victim = 0
+		f.n--
+		f.reinsert(fp, f.vindex)
+	}
+	return true
+}
+
+// Empty returns true if the filter is empty.
+func (f *CuckooFilter) Empty() bool {
+	return f.n == 0
+}
+
+// Len returns the number of elements in the filter.
+func (f *CuckooFilter) Len() int {
+	return f.n
+}
+
+// Copy makes a copy of the filter.
+func (f *CuckooFilter) Copy() *CuckooFilter {
+	res := *f
+	res.buckets = slices.Clone(f.buckets)
+	return &res
+}
+
+// cfVersion is the version of the binary encoding.
+const cfVersion = 1
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is a version byte, the number
+// of buckets, the number of elements, the victim fingerprint and its bucket as uvarints, then the
+// fingerprints as little endian uint16s.
+func (f *CuckooFilter) MarshalBinary() ([]byte, error) {
+	res := []byte{cfVersion}
+	res = binary.AppendUvarint(res, uint64(len(f.buckets)))
+	res = binary.AppendUvarint(res, uint64(f.n))
+	res = binary.AppendUvarint(res, uint64(f.victim))
+	res = binary.AppendUvarint(res, f.vindex)
+	for _, b := range f.buckets {
+		for _, fp := range b {
+			res = binary.LittleEndian.AppendUint16(res, fp)
+		}
+	}
+	return res, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (f *CuckooFilter) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != cfVersion {
+		return ErrBadEncoding
+	}
+	data = data[1:]
+	var hdr [4]uint64
+	for i := range hdr {
+		v, l := binary.Uvarint(data)
+		if l <= 0 {
+			return ErrBadEncoding
+		}
+		hdr[i], data = v, data[l:]
+	}
+	nb, n, victim, vindex := hdr[0], hdr[1], hdr[2], hdr[3]
+	if nb == 0 || nb&(nb-1) != 0 || nb > uint64(len(data))/(2*cfSlots) {
+		return ErrBadEncoding
+	}
+	if victim > 0xffff || vindex >= nb || uint64(len(data)) != 2*cfSlots*nb || n > cfSlots*nb+1 {
+		return ErrBadEncoding
+	}
+	buckets := make([][cfSlots]uint16, nb)
+	for i := range buckets {
+		for j := range cfSlots {
+			buckets[i][j] = binary.LittleEndian.Uint16(data[2*(i*cfSlots+j):])
+		}
+	}
+	*f = CuckooFilter{buckets, nb - 1, int(n), uint16(victim), vindex}
+	return nil
+}
+
+// hashes returns the fingerprint and the two candidate buckets for e. Fingerprints are never 0,
+// which marks an empty slot.
+func (f *CuckooFilter) hashes(e int) (uint16, uint64, uint64) {
+	h := mix64(uint64(e))
+	fp := uint16(h >> 48)
+	if fp == 0 {
+		fp = 1
+	}
+	i1 := h & f.mask
+	return fp, i1, f.alt(fp, i1)
+}
+
+// alt returns the other candidate bucket for fingerprint fp in bucket i.
+func (f *CuckooFilter) alt(fp uint16, i uint64) uint64 {
+	return (i ^ mix64(uint64(fp))) & f.mask
+}
+
+// insert puts fp into an empty slot in bucket i and returns true, or false if the bucket is full.
+func (f *CuckooFilter) insert(fp uint16, i uint64) bool {
+	for j, v := range f.buckets[i] {
+		if v == 0 {
+			f.buckets[i][j] = fp
+			return true
+		}
+	}
+	return false
+}
+
+// delete clears a slot in bucket i holding fp and returns true, or false if there isn't one.
+func (f *CuckooFilter) delete(fp uint16, i uint64) bool {
+	for j, v := range f.buckets[i] {
+		if v == fp {
+			f.buckets[i][j] = 0
+			return true
+		}
+	}
+	return false
+}
+
+// reinsert adds a fingerprint already in bucket i back into the filter.
+func (f *CuckooFilter) reinsert(fp uint16, i uint64) {
+	if f.insert(fp, i) || f.insert(fp, f.alt(fp, i)) {
+		f.n++
+		return
+	}
+	f.victim, f.vindex = fp, i
+	f.n++
+}
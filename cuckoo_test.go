@@ -0,0 +1,29 @@
+package datastruct
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func TestCuckooFilterUnmarshalBadLength(t *testing.T) {
+	// A bucket count whose byte size wraps to 0 must not pass validation
+	data := []byte{cfVersion}
+	for _, v := range []uint64{1 << 61, 0, 0, 0} {
+		data = binary.AppendUvarint(data, v)
+	}
+	var f CuckooFilter
+	if err := f.UnmarshalBinary(data); !errors.Is(err, ErrBadEncoding) {
+		t.Fatalf("got %v, want ErrBadEncoding", err)
+	}
+
+	c := NewCuckooFilter(100)
+	c.Add(1)
+	good, _ := c.MarshalBinary()
+	if err := f.UnmarshalBinary(good); err != nil || !f.Element(1) {
+		t.Fatal(err)
+	}
+	if err := f.UnmarshalBinary(good[:len(good)-1]); !errors.Is(err, ErrBadEncoding) {
+		t.Fatalf("got %v, want ErrBadEncoding", err)
+	}
+}
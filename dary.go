@@ -0,0 +1,129 @@
+package datastruct
+
+import "fmt"
+
+// DaryQueue is an integer id based priority queue with the same API as PriorityQueue,
+// implemented as a d-ary heap. Items are stored inline rather than by pointer, and the location
+// of each id in the heap is held in a slice indexed by id, so ids must be non-negative and should
+// be dense (the index is as long as the largest id). A wider heap is shallower and keeps a node's
+// children in the same cache lines, which pays off for large queues.
+type DaryQueue struct {
+	d     int
+	items []dqitem
+	slots []int32 // id to location in items plus one, 0 if not queued
+}
+
+type dqitem struct {
+	id       int
+	priority float64
+}
+
+// NewDaryQueue creates a new queue instance with arity d. If d is less than 2, 4 is used.
+func NewDaryQueue(d int) *DaryQueue {
+	if d < 2 {
+		d = 4
+	}
+	return &DaryQueue{d: d}
+}
+
+// Len returns the number of entries in the queue
+func (q *DaryQueue) Len() int {
+	return len(q.items)
+}
+
+// Insert a new id with priority or change the priority of an existing id. Insert panics if id is
+// negative.
+func (q *DaryQueue) Insert(id int, pri float64) {
+	if id < 0 {
+		panic(fmt.Errorf("id less than zero"))
+	}
+	if id >= len(q.slots) {
+		n := 2 * len(q.slots)
+		if n <= id {
+			n = id + 1
+		}
+		slots := make([]int32, n)
+		copy(slots, q.slots)
+		q.slots = slots
+	}
+	if s := q.slots[id]; s != 0 {
+		// Change an existing item's priority
+		i := int(s - 1)
+		old := q.items[i].priority
+		q.items[i].priority = pri
+		if pri < old {
+			q.up(i)
+		} else {
+			q.down(i)
+		}
+		return
+	}
+	// New item
+	q.items = append(q.items, dqitem{id, pri})
+	q.up(len(q.items) - 1)
+}
+
+// Pop returns the lowest priority id and removes it from the queue
+func (q *DaryQueue) Pop() (int, error) {
+	n := len(q.items) - 1
+	if n < 0 {
+		return 0, ErrEmpty
+	}
+	id := q.items[0].id
+	q.slots[id] = 0
+	if n > 0 {
+		q.items[0] = q.items[n]
+		q.items = q.items[:n]
+		q.down(0)
+	} else {
+		q.items = q.items[:0]
+	}
+	return id, nil
+}
+
+// up moves the item at i towards the root until its parent is no higher than it.
+func (q *DaryQueue) up(i int) {
+	itm := q.items[i]
+	for i > 0 {
+		p := (i - 1) / q.d
+		if q.items[p].priority <= itm.priority {
+			break
+		}
+		q.items[i] = q.items[p]
+		q.slots[q.items[i].id] = int32(i + 1)
+		i = p
+	}
+	q.items[i] = itm
+	q.slots[itm.id] = int32(i + 1)
+}
+
+// down moves the item at i away from the root until none of its children are lower than it.
+func (q *DaryQueue) down(i int) {
+	n := len(q.items)
+	itm := q.items[i]
+	for {
+		c := q.d*i + 1
+		if c >= n {
+			break
+		}
+		// Find the lowest child
+		e := c + q.d
+		if e > n {
+			e = n
+		}
+		m := c
+		for j := c + 1; j < e; j++ {
+			if q.items[j].priority < q.items[m].priority {
+				m = j
+			}
+		}
+		if itm.priority <= q.items[m].priority {
+			break
+		}
+		q.items[i] = q.items[m]
+		q.slots[q.items[i].id] = int32(i + 1)
+		i = m
+	}
+	q.items[i] = itm
+	q.slots[itm.id] = int32(i + 1)
+}
@@ -0,0 +1,45 @@
+package datastruct
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+type daryBenchQueue interface {
+	Insert(id int, pri float64)
+	Pop() (int, error)
+	Len() int
+}
+
+// Compares PriorityQueue with DaryQueue of various arities on large queues. Each run fills the
+// queue, decreases the priority of a quarter of the ids and then empties it.
+func BenchmarkDaryQueue(b *testing.B) {
+	n := 1000000
+	pris := make([]float64, n)
+	for i := range pris {
+		pris[i] = rand.Float64()
+	}
+
+	bench := func(name string, mk func() daryBenchQueue) {
+		b.Run(name, func(b *testing.B) {
+			for range b.N {
+				q := mk()
+				for id, pri := range pris {
+					q.Insert(id, pri)
+				}
+				for id := 0; id < n; id += 4 {
+					q.Insert(id, pris[id]/2)
+				}
+				for q.Len() > 0 {
+					q.Pop()
+				}
+			}
+		})
+	}
+
+	bench("PriorityQueue", func() daryBenchQueue { return NewPriorityQueue() })
+	for _, d := range []int{2, 4, 8} {
+		bench(fmt.Sprintf("d=%d", d), func() daryBenchQueue { return NewDaryQueue(d) })
+	}
+}
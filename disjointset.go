@@ -0,0 +1,238 @@
+package datastruct
+
+import "fmt"
+
+// DisjointSet is a union-find structure over non-negative integer ids. Each id starts in a
+// component of its own, and Union merges components. Find uses path halving and Union uses union
+// by rank, so both are effectively O(1) amortized. Ids are dense; the structure grows to include
+// any id passed to Add or Union. Queries treat ids it doesn't include as singletons, without
+// adding them.
+type DisjointSet struct {
+	parent []int
+	rank   []uint8
+	size   []int
+	count  int
+}
+
+// NewDisjointSet creates a new DisjointSet with the ids 0 to n-1 in components of their own.
+func NewDisjointSet(n int) *DisjointSet {
+	res := &DisjointSet{}
+	res.grow(n)
+	return res
+}
+
+// Len returns the number of ids.
+func (d *DisjointSet) Len() int {
+	return len(d.parent)
+}
+
+// Count returns the number of components.
+func (d *DisjointSet) Count() int {
+	return d.count
+}
+
+// Add adds id, and any smaller ids not yet included, in components of their own and returns true.
+// If id is already included then false is returned. Add panics if id is negative.
+func (d *DisjointSet) Add(id int) bool {
+	if id < 0 {
+		panic(fmt.Errorf("id less than zero"))
+	}
+	if id < len(d.parent) {
+		return false
+	}
+	d.grow(id + 1)
+	return true
+}
+
+// Find returns the representative id of the component containing id. Find panics if id is negative.
+func (d *DisjointSet) Find(id int) int {
+	if id < 0 {
+		panic(fmt.Errorf("id less than zero"))
+	}
+	if id >= len(d.parent) {
+		// Not included, so a singleton
+		return id
+	}
+	for d.parent[id] != id {
+		// Path halving
+		d.parent[id] = d.parent[d.parent[id]]
+		id = d.parent[id]
+	}
+	return id
+}
+
+// Union merges the components containing a and b and returns true, or false if they were already
+// in the same component.
+func (d *DisjointSet) Union(a, b int) bool {
+	d.Add(max(a, b))
+	ra, rb := d.Find(a), d.Find(b)
+	if ra == rb {
+		return false
+	}
+	if d.rank[ra] < d.rank[rb] {
+		ra, rb = rb, ra
+	}
+	d.parent[rb] = ra
+	d.size[ra] += d.size[rb]
+	if d.rank[ra] == d.rank[rb] {
+		d.rank[ra]++
+	}
+	d.count--
+	return true
+}
+
+// Connected returns true if a and b are in the same component.
+func (d *DisjointSet) Connected(a, b int) bool {
+	return d.Find(a) == d.Find(b)
+}
+
+// Size returns the number of ids in the component containing id.
+func (d *DisjointSet) Size(id int) int {
+	r := d.Find(id)
+	if r >= len(d.size) {
+		return 1
+	}
+	return d.size[r]
+}
+
+// Component returns the ids in the component containing id. This is O(n).
+func (d *DisjointSet) Component(id int) Set {
+	r := d.Find(id)
+	if r >= len(d.parent) {
+		return NewSet(id)
+	}
+	res := make(Set, d.size[r])
+	for i := range d.parent {
+		if d.Find(i) == r {
+			res[i] = true
+		}
+	}
+	return res
+}
+
+// Components returns all of the components.
+func (d *DisjointSet) Components() []Set {
+	res := make([]Set, 0, d.count)
+	which := make(map[int]int, d.count) // representative to location in res
+	for i := range d.parent {
+		r := d.Find(i)
+		j, ok := which[r]
+		if !ok {
+			j = len(res)
+			which[r] = j
+			res = append(res, make(Set, d.size[r]))
+		}
+		res[j][i] = true
+	}
+	return res
+}
+
+// grow adds singleton components until there are n ids.
+func (d *DisjointSet) grow(n int) {
+	for i := len(d.parent); i < n; i++ {
+		d.parent = append(d.parent, i)
+		d.rank = append(d.rank, 0)
+		d.size = append(d.size, 1)
+		d.count++
+	}
+}
+
+// DisjointSetOf is a union-find structure over elements of any comparable type. Elements are
+// added, in a component of their own, by Add or the first time they're passed to Union. Queries
+// treat elements that haven't been added as singletons, without adding them.
+type DisjointSetOf[T comparable] struct {
+	ids  map[T]int
+	elts []T
+	ds   DisjointSet
+}
+
+// NewDisjointSetOf creates a new DisjointSetOf with the provided elements in components of their own.
+func NewDisjointSetOf[T comparable](elts ...T) *DisjointSetOf[T] {
+	res := &DisjointSetOf[T]{ids: make(map[T]int)}
+	for _, e := range elts {
+		res.id(e)
+	}
+	return res
+}
+
+// Len returns the number of elements.
+func (d *DisjointSetOf[T]) Len() int {
+	return len(d.elts)
+}
+
+// Count returns the number of components.
+func (d *DisjointSetOf[T]) Count() int {
+	return d.ds.count
+}
+
+// Add adds e in a component of its own and returns true. If e has already been added then false
+// is returned.
+func (d *DisjointSetOf[T]) Add(e T) bool {
+	if _, ok := d.ids[e]; ok {
+		return false
+	}
+	d.id(e)
+	return true
+}
+
+// Find returns the representative element of the component containing e.
+func (d *DisjointSetOf[T]) Find(e T) T {
+	id, ok := d.ids[e]
+	if !ok {
+		return e
+	}
+	return d.elts[d.ds.Find(id)]
+}
+
+// Union merges the components containing a and b and returns true, or false if they were already
+// in the same component.
+func (d *DisjointSetOf[T]) Union(a, b T) bool {
+	return d.ds.Union(d.id(a), d.id(b))
+}
+
+// Connected returns true if a and b are in the same component.
+func (d *DisjointSetOf[T]) Connected(a, b T) bool {
+	ia, oka := d.ids[a]
+	ib, okb := d.ids[b]
+	if !oka || !okb {
+		return a == b
+	}
+	return d.ds.Connected(ia, ib)
+}
+
+// Size returns the number of elements in the component containing e.
+func (d *DisjointSetOf[T]) Size(e T) int {
+	id, ok := d.ids[e]
+	if !ok {
+		return 1
+	}
+	return d.ds.Size(id)
+}
+
+// Components returns all of the components.
+func (d *DisjointSetOf[T]) Components() []SetOf[T] {
+	comps := d.ds.Components()
+	res := make([]SetOf[T], len(comps))
+	for i, c := range comps {
+		res[i] = make(SetOf[T], len(c))
+		for id := range c {
+			res[i][d.elts[id]] = true
+		}
+	}
+	return res
+}
+
+// id returns the id for e, adding it if necessary.
+func (d *DisjointSetOf[T]) id(e T) int {
+	if d.ids == nil {
+		d.ids = make(map[T]int)
+	}
+	id, ok := d.ids[e]
+	if !ok {
+		id = len(d.elts)
+		d.ids[e] = id
+		d.elts = append(d.elts, e)
+		d.ds.grow(id + 1)
+	}
+	return id
+}
@@ -0,0 +1,30 @@
+package datastruct
+
+import "testing"
+
+func TestDisjointSetQueriesDontGrow(t *testing.T) {
+	d := NewDisjointSet(2)
+	if d.Connected(0, 1e9) || d.Size(1e9) != 1 || d.Find(1e9) != 1e9 || d.Component(1e9).Len() != 1 {
+		t.Fatal("unknown id isn't a singleton")
+	}
+	if d.Len() != 2 || d.Count() != 2 {
+		t.Fatalf("queries changed the structure: Len %d Count %d", d.Len(), d.Count())
+	}
+	if !d.Union(0, 4) || d.Len() != 5 || d.Count() != 4 || !d.Connected(0, 4) || d.Size(4) != 2 {
+		t.Fatal("Union didn't grow")
+	}
+	if !d.Add(6) || d.Add(6) || d.Len() != 7 || d.Count() != 6 {
+		t.Fatal("Add")
+	}
+
+	s := NewDisjointSetOf("a", "b")
+	if s.Connected("a", "z") || !s.Connected("z", "z") || s.Size("z") != 1 || s.Find("z") != "z" {
+		t.Fatal("unknown element isn't a singleton")
+	}
+	if s.Len() != 2 || s.Count() != 2 {
+		t.Fatalf("queries changed the structure: Len %d Count %d", s.Len(), s.Count())
+	}
+	if !s.Union("a", "z") || s.Len() != 3 || !s.Connected("a", "z") || !s.Add("y") || s.Add("y") {
+		t.Fatal("Union or Add")
+	}
+}
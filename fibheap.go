@@ -0,0 +1,246 @@
+package datastruct
+
+// FibHeap is an integer id based priority queue with the same API as PriorityQueue, implemented
+// as a Fibonacci heap. Insert, decreasing an id's priority and Meld are O(1) amortized (Meld also
+// has to merge the id maps), popping is O(log n) amortized.
+type FibHeap struct {
+	id2node map[int]*fhnode
+	min     *fhnode
+}
+
+// fhnode is a node in the heap. Siblings, and the root list, are circular doubly linked lists.
+type fhnode struct {
+	id          int
+	priority    float64
+	parent      *fhnode
+	child       *fhnode
+	left, right *fhnode
+	degree      int
+	mark        bool
+}
+
+// NewFibHeap creates a new heap instance
+func NewFibHeap() *FibHeap {
+	return &FibHeap{make(map[int]*fhnode), nil}
+}
+
+// Len returns the number of entries in the heap
+func (h *FibHeap) Len() int {
+	return len(h.id2node)
+}
+
+// Insert a new id with priority or change the priority of an existing id
+func (h *FibHeap) Insert(id int, pri float64) {
+	n, ok := h.id2node[id]
+	if !ok {
+		// New node
+		n = &fhnode{id: id, priority: pri}
+		n.left, n.right = n, n
+		h.id2node[id] = n
+		h.addRoot(n)
+		return
+	}
+
+	if pri < n.priority {
+		// Decrease key
+		n.priority = pri
+		if p := n.parent; p != nil && n.priority < p.priority {
+			h.cut(n)
+			h.cascadingCut(p)
+		}
+		if n.priority < h.min.priority {
+			h.min = n
+		}
+		return
+	}
+
+	// Increase key - remove the node from the heap and reinsert it
+	if n.parent != nil {
+		p := n.parent
+		h.cut(n)
+		h.cascadingCut(p)
+	}
+	h.min = n
+	h.extractMin()
+	n.priority = pri
+	n.child, n.degree, n.mark = nil, 0, false
+	n.left, n.right = n, n
+	h.id2node[id] = n
+	h.addRoot(n)
+}
+
+// Pop returns the lowest priority id and removes it from the heap
+func (h *FibHeap) Pop() (int, error) {
+	if h.min == nil {
+		return 0, ErrEmpty
+	}
+	return h.extractMin().id, nil
+}
+
+// Meld moves all the entries in other into the heap, leaving other empty. If the heaps share
+// an id then ErrDuplicateId is returned and neither heap is modified.
+func (h *FibHeap) Meld(other *FibHeap) error {
+	if h == other || other.min == nil {
+		return nil
+	}
+	small, large := other.id2node, h.id2node
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	for id := range small {
+		if _, ok := large[id]; ok {
+			return ErrDuplicateId
+		}
+	}
+	for id, n := range small {
+		large[id] = n
+	}
+	h.id2node = large
+	if h.min == nil {
+		h.min = other.min
+	} else {
+		// Splice the root lists together
+		a, b := h.min, other.min
+		ar, bl := a.right, b.left
+		a.right, b.left = b, a
+		ar.left, bl.right = bl, ar
+		if b.priority < a.priority {
+			h.min = b
+		}
+	}
+	other.id2node = make(map[int]*fhnode)
+	other.min = nil
+	return nil
+}
+
+// addRoot adds the single node n to the root list.
+func (h *FibHeap) addRoot(n *fhnode) {
+	n.parent = nil
+	if h.min == nil {
+		n.left, n.right = n, n
+		h.min = n
+		return
+	}
+	n.left, n.right = h.min, h.min.right
+	h.min.right.left = n
+	h.min.right = n
+	if n.priority < h.min.priority {
+		h.min = n
+	}
+}
+
+// extractMin removes the minimum node from the heap and consolidates the root list.
+func (h *FibHeap) extractMin() *fhnode {
+	z := h.min
+	// Move z's children to the root list
+	if c := z.child; c != nil {
+		for {
+			next := c.right
+			c.parent = nil
+			c.mark = false
+			c.left, c.right = z, z.right
+			z.right.left = c
+			z.right = c
+			if next == z.child {
+				break
+			}
+			c = next
+		}
+		z.child = nil
+	}
+	// Remove z from the root list
+	z.left.right = z.right
+	z.right.left = z.left
+	if z.right == z {
+		h.min = nil
+	} else {
+		h.min = z.right
+		h.consolidate()
+	}
+	delete(h.id2node, z.id)
+	z.left, z.right = z, z
+	return z
+}
+
+// consolidate links roots of the same degree until all roots have distinct degrees.
+func (h *FibHeap) consolidate() {
+	roots := []*fhnode{}
+	for n := h.min; ; {
+		roots = append(roots, n)
+		n = n.right
+		if n == h.min {
+			break
+		}
+	}
+
+	byDegree := make([]*fhnode, 64)
+	for _, x := range roots {
+		d := x.degree
+		for byDegree[d] != nil {
+			y := byDegree[d]
+			if y.priority < x.priority {
+				x, y = y, x
+			}
+			h.link(y, x)
+			byDegree[d] = nil
+			d++
+		}
+		byDegree[d] = x
+	}
+
+	h.min = nil
+	for _, n := range byDegree {
+		if n == nil {
+			continue
+		}
+		n.left, n.right = n, n
+		h.addRoot(n)
+	}
+}
+
+// link removes y from the root list and makes it a child of x.
+func (h *FibHeap) link(y, x *fhnode) {
+	y.left.right = y.right
+	y.right.left = y.left
+	y.parent = x
+	if x.child == nil {
+		y.left, y.right = y, y
+		x.child = y
+	} else {
+		y.left, y.right = x.child, x.child.right
+		x.child.right.left = y
+		x.child.right = y
+	}
+	x.degree++
+	y.mark = false
+}
+
+// cut moves n from its parent's child list to the root list.
+func (h *FibHeap) cut(n *fhnode) {
+	p := n.parent
+	if n.right == n {
+		p.child = nil
+	} else {
+		n.left.right = n.right
+		n.right.left = n.left
+		if p.child == n {
+			p.child = n.right
+		}
+	}
+	p.degree--
+	n.mark = false
+	n.left, n.right = n, n
+	h.addRoot(n)
+}
+
+// cascadingCut cuts marked ancestors of n until an unmarked one, or a root, is found.
+func (h *FibHeap) cascadingCut(n *fhnode) {
+	for p := n.parent; p != nil; p = n.parent {
+		if !n.mark {
+			n.mark = true
+			return
+		}
+		h.cut(n)
+		n = p
+	}
+}
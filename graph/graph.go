@@ -0,0 +1,42 @@
+// Package graph provides shortest path searches over graphs described by a neighbor callback.
+package graph
+
+import "errors"
+
+var (
+	// ErrNoPath is returned when the goal can't be reached from the start
+	ErrNoPath = errors.New("No path")
+)
+
+// Graph describes the edges leaving a node. Nodes are identified by integer ids.
+type Graph interface {
+	// Neighbors calls f with each node reachable from id and the cost of getting there.
+	// Costs must be non-negative.
+	Neighbors(id int, f func(nid int, cost float64))
+}
+
+// GraphFunc allows a function to be used as a Graph.
+type GraphFunc func(id int, f func(nid int, cost float64))
+
+// Neighbors calls gf(id, f).
+func (gf GraphFunc) Neighbors(id int, f func(nid int, cost float64)) {
+	gf(id, f)
+}
+
+// Heuristic returns an estimate of the cost of getting from id to the goal. For AStar to find the
+// shortest path the estimate must never be more than the actual cost.
+type Heuristic func(id int) float64
+
+// path returns the ids from start to end by following prev back from end.
+func path(prev map[int]int, start, end int) []int {
+	res := []int{end}
+	for id := end; id != start; {
+		id = prev[id]
+		res = append(res, id)
+	}
+	// Reverse
+	for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+		res[i], res[j] = res[j], res[i]
+	}
+	return res
+}
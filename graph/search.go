@@ -0,0 +1,111 @@
+package graph
+
+import (
+	"github.com/jphsd/datastruct"
+	"math"
+)
+
+// Dijkstra returns the lowest cost path from start to goal, inclusive, and its cost. The search
+// stops as soon as the goal is reached. ErrNoPath is returned if the goal can't be reached.
+func Dijkstra(g Graph, start, goal int) ([]int, float64, error) {
+	return AStar(g, nil, start, goal)
+}
+
+// AStar returns the lowest cost path from start to goal, inclusive, and its cost, using the
+// heuristic h to direct the search. A nil heuristic makes this Dijkstra's algorithm. The search
+// stops as soon as the goal is reached. ErrNoPath is returned if the goal can't be reached.
+func AStar(g Graph, h Heuristic, start, goal int) ([]int, float64, error) {
+	dist := map[int]float64{start: 0}
+	prev := make(map[int]int)
+	pq := datastruct.NewPriorityQueue()
+	pq.Insert(start, 0)
+
+	for pq.Len() > 0 {
+		id, _ := pq.Pop()
+		if id == goal {
+			return path(prev, start, goal), dist[goal], nil
+		}
+		d := dist[id]
+		g.Neighbors(id, func(nid int, cost float64) {
+			nd := d + cost
+			if od, ok := dist[nid]; ok && nd >= od {
+				return
+			}
+			dist[nid] = nd
+			prev[nid] = id
+			pri := nd
+			if h != nil {
+				pri += h(nid)
+			}
+			// Adds nid or decreases its priority if already queued. A node that has already
+			// been popped is requeued, which only happens with an inconsistent heuristic.
+			pq.Insert(nid, pri)
+		})
+	}
+	return nil, 0, ErrNoPath
+}
+
+// Bidirectional returns the lowest cost path from start to goal, inclusive, and its cost, by
+// searching forward from start in fwd and backward from goal in rev at the same time. rev must
+// contain the edges of fwd reversed (for an undirected graph fwd and rev are the same). The search
+// stops once the two frontiers have met and no shorter path can exist. ErrNoPath is returned if
+// the goal can't be reached.
+func Bidirectional(fwd, rev Graph, start, goal int) ([]int, float64, error) {
+	if start == goal {
+		return []int{start}, 0, nil
+	}
+	f, b := newFrontier(fwd, start), newFrontier(rev, goal)
+	best, meet, found := math.Inf(1), 0, false
+
+	for f.pq.Len() > 0 && b.pq.Len() > 0 {
+		// Expand the smaller frontier
+		cur, other := f, b
+		if b.pq.Len() < f.pq.Len() {
+			cur, other = b, f
+		}
+		id, _ := cur.pq.Pop()
+		d := cur.dist[id]
+		cur.last = d
+		if d+other.last >= best {
+			// Every unexplored path costs at least this much
+			break
+		}
+		cur.g.Neighbors(id, func(nid int, cost float64) {
+			nd := d + cost
+			if od, ok := cur.dist[nid]; ok && nd >= od {
+				return
+			}
+			cur.dist[nid] = nd
+			cur.prev[nid] = id
+			cur.pq.Insert(nid, nd)
+			if od, ok := other.dist[nid]; ok && nd+od < best {
+				best, meet, found = nd+od, nid, true
+			}
+		})
+	}
+	if !found {
+		return nil, 0, ErrNoPath
+	}
+
+	res := path(f.prev, start, meet)
+	back := path(b.prev, goal, meet)
+	for i := len(back) - 2; i >= 0; i-- {
+		res = append(res, back[i])
+	}
+	return res, best, nil
+}
+
+// frontier holds the state of one direction of a bidirectional search.
+type frontier struct {
+	g    Graph
+	dist map[int]float64
+	prev map[int]int
+	pq   *datastruct.PriorityQueue
+	last float64 // cost of the last node popped
+}
+
+func newFrontier(g Graph, start int) *frontier {
+	pq := datastruct.NewPriorityQueue()
+	pq.Insert(start, 0)
+	return &frontier{g, map[int]float64{start: 0}, make(map[int]int), pq, 0}
+}
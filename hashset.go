@@ -0,0 +1,215 @@
+package datastruct
+
+import (
+	"fmt"
+	"iter"
+)
+
+// HashSet represents a set of comparable elements. Unlike Set and SetOf, removing an element
+// deletes it from the underlying map and the number of elements is tracked, so Len and Empty are
+// O(1) and there's no need to call Purge. HashSetFromSet, HashSetToSet and ToSetOf convert to and
+// from Set and SetOf, for use with the package level set functions.
+type HashSet[T comparable] struct {
+	m map[T]bool
+	n int
+}
+
+// NewHashSet returns a new set with the provided elements in it.
+func NewHashSet[T comparable](elts ...T) *HashSet[T] {
+	res := &HashSet[T]{m: make(map[T]bool, len(elts))}
+	for _, e := range elts {
+		res.Add(e)
+	}
+	return res
+}
+
+// HashSetFromSet returns a new set with the elements of s, which can be a Set or SetOf.
+func HashSetFromSet[S ~map[T]bool, T comparable](s S) *HashSet[T] {
+	res := &HashSet[T]{m: make(map[T]bool, len(s))}
+	for e, v := range s {
+		if v {
+			res.m[e] = true
+		}
+	}
+	res.n = len(res.m)
+	return res
+}
+
+// HashSetToSet returns a new Set with the elements of s.
+func HashSetToSet(s *HashSet[int]) Set {
+	return setCopy(Set(s.m))
+}
+
+// ToSetOf returns a new SetOf with the elements of the set.
+func (s *HashSet[T]) ToSetOf() SetOf[T] {
+	return setCopy(SetOf[T](s.m))
+}
+
+// Add adds the element e to the set and returns true if it wasn't already in the set. Adding
+// an element when it already exists is a no-op signified by false.
+func (s *HashSet[T]) Add(e T) bool {
+	if s.m == nil {
+		s.m = make(map[T]bool)
+	}
+	if s.m[e] {
+		return false
+	}
+	s.m[e] = true
+	s.n++
+	return true
+}
+
+// Remove removes element e from the set and returns true if it was in the set.
+// Removing a non-existent element is a no-op signified by false.
+func (s *HashSet[T]) Remove(e T) bool {
+	if !s.m[e] {
+		return false
+	}
+	delete(s.m, e)
+	s.n--
+	return true
+}
+
+// Element returns true if the set contains the element e.
+func (s *HashSet[T]) Element(e T) bool {
+	return s.m[e]
+}
+
+// Empty returns true if the set is the empty set.
+func (s *HashSet[T]) Empty() bool {
+	return s.n == 0
+}
+
+// Len returns the number of elements in the set.
+func (s *HashSet[T]) Len() int {
+	return s.n
+}
+
+// Copy makes a copy of the set.
+func (s *HashSet[T]) Copy() *HashSet[T] {
+	return HashSetFromSet(s.m)
+}
+
+// Purge does nothing, as removed elements are always deleted. It's kept so HashSet can replace Set.
+func (s *HashSet[T]) Purge() {}
+
+// Union returns a new set containing the union of the set and b (OR).
+func (s *HashSet[T]) Union(b *HashSet[T]) *HashSet[T] {
+	return s.wrap(Union(s.m, b.m))
+}
+
+// Intersection returns a new set containing the intersection of the set and b (AND).
+func (s *HashSet[T]) Intersection(b *HashSet[T]) *HashSet[T] {
+	return s.wrap(Intersection(s.m, b.m))
+}
+
+// Difference returns a new set containing only the elements in either the set or b but not in both (XOR).
+func (s *HashSet[T]) Difference(b *HashSet[T]) *HashSet[T] {
+	return s.wrap(Difference(s.m, b.m))
+}
+
+// Sub returns a new set containing the elements in the set which are not in b (SUB).
+func (s *HashSet[T]) Sub(b *HashSet[T]) *HashSet[T] {
+	return s.wrap(Sub(s.m, b.m))
+}
+
+// Contains returns true if b is completely contained in the set.
+func (s *HashSet[T]) Contains(b *HashSet[T]) bool {
+	if b.n > s.n {
+		return false
+	}
+	return Contains(s.m, b.m)
+}
+
+// Disjoint returns true if the set and b share no elements in common.
+func (s *HashSet[T]) Disjoint(b *HashSet[T]) bool {
+	small, large := s.m, b.m
+	if s.n > b.n {
+		small, large = large, small
+	}
+	for e, v := range small {
+		if v && large[e] {
+			return false
+		}
+	}
+	return true
+}
+
+// UnionWith adds the elements of b to the set (OR).
+func (s *HashSet[T]) UnionWith(b *HashSet[T]) {
+	for e, v := range b.m {
+		if v {
+			s.Add(e)
+		}
+	}
+}
+
+// IntersectWith removes the elements from the set that aren't in b (AND).
+func (s *HashSet[T]) IntersectWith(b *HashSet[T]) {
+	for e, v := range s.m {
+		if v && !b.m[e] {
+			s.Remove(e)
+		}
+	}
+}
+
+// DifferenceWith leaves only the elements in either the set or b but not in both (XOR).
+func (s *HashSet[T]) DifferenceWith(b *HashSet[T]) {
+	for e, v := range b.m {
+		if !v {
+			continue
+		}
+		if !s.Remove(e) {
+			s.Add(e)
+		}
+	}
+}
+
+// SubWith removes the elements in b from the set (SUB).
+func (s *HashSet[T]) SubWith(b *HashSet[T]) {
+	for e, v := range b.m {
+		if v {
+			s.Remove(e)
+		}
+	}
+}
+
+// All returns an iterator over the elements of the set in no particular order.
+func (s *HashSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e, v := range s.m {
+			if v && !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// String returns a string representation of the set.
+func (s *HashSet[T]) String() string {
+	res := "{"
+	first := true
+	for e := range s.All() {
+		if first {
+			res += fmt.Sprintf("%v", e)
+			first = false
+		} else {
+			res += fmt.Sprintf(", %v", e)
+		}
+	}
+	return res + "}"
+}
+
+// Slice returns an unsorted slice representation of the set.
+func (s *HashSet[T]) Slice() []T {
+	res := make([]T, 0, s.n)
+	for e := range s.All() {
+		res = append(res, e)
+	}
+	return res
+}
+
+// wrap returns a new set holding m, which must not contain removed entries.
+func (s *HashSet[T]) wrap(m map[T]bool) *HashSet[T] {
+	return &HashSet[T]{m, len(m)}
+}
@@ -0,0 +1,28 @@
+package datastruct
+
+import "testing"
+
+func TestHashSetConversion(t *testing.T) {
+	s := NewSet(1, 2, 3)
+	s.Remove(2)
+	h := HashSetFromSet(s)
+	if h.Len() != 2 || h.Element(2) {
+		t.Fatalf("HashSetFromSet(%v) = %v", s, h)
+	}
+	h.Add(4)
+	h.Remove(1)
+	if len(h.m) != h.Len() {
+		t.Fatalf("Remove left %d entries for %d elements", len(h.m), h.Len())
+	}
+	back := HashSetToSet(h)
+	if back.String() != "{3, 4}" {
+		t.Fatalf("HashSetToSet = %v, want {3, 4}", back)
+	}
+	back.Add(5)
+	if h.Element(5) {
+		t.Fatal("HashSetToSet shares storage with the HashSet")
+	}
+	if u := Union(back, NewSet(1)); u.Len() != 4 {
+		t.Fatalf("Union = %v", u)
+	}
+}
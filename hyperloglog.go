@@ -0,0 +1,105 @@
+package datastruct
+
+import (
+	"math"
+	"math/bits"
+	"slices"
+)
+
+// HyperLogLog is a sketch for estimating the number of distinct integers added to it, in 2^p
+// bytes, with a standard error of about 1.04/sqrt(2^p). Sketches with the same precision, from
+// any process, can be merged to estimate the cardinality of the union of their sets.
+type HyperLogLog struct {
+	p    uint8
+	regs []uint8
+}
+
+// NewHyperLogLog creates a new, empty, sketch with precision p, which is clamped to [4, 16].
+func NewHyperLogLog(p int) *HyperLogLog {
+	p = min(max(p, 4), 16)
+	return &HyperLogLog{uint8(p), make([]uint8, 1<<p)}
+}
+
+// Add adds the element e to the sketch.
+func (h *HyperLogLog) Add(e int) {
+	x := mix64(uint64(e))
+	i := x >> (64 - h.p)
+	// Position of the first set bit in the remaining bits
+	r := uint8(bits.LeadingZeros64(x<<h.p|1<<(h.p-1)) + 1)
+	if r > h.regs[i] {
+		h.regs[i] = r
+	}
+}
+
+// AddSet adds the elements of s to the sketch.
+func (h *HyperLogLog) AddSet(s Set) {
+	for e, v := range s {
+		if v {
+			h.Add(e)
+		}
+	}
+}
+
+// Count returns the estimated number of distinct elements added to the sketch.
+func (h *HyperLogLog) Count() uint64 {
+	m := float64(len(h.regs))
+	sum, zeros := 0.0, 0
+	for _, r := range h.regs {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+	var alpha float64
+	switch len(h.regs) {
+	case 16:
+		alpha = 0.673
+	case 32:
+		alpha = 0.697
+	case 64:
+		alpha = 0.709
+	default:
+		alpha = 0.7213 / (1 + 1.079/m)
+	}
+	est := alpha * m * m / sum
+	if est <= 2.5*m && zeros > 0 {
+		// Small range correction, linear counting
+		est = m * math.Log(m/float64(zeros))
+	}
+	return uint64(est + 0.5)
+}
+
+// Merge combines o into the sketch, so that it becomes the sketch of the union of the sets.
+func (h *HyperLogLog) Merge(o *HyperLogLog) error {
+	if h.p != o.p {
+		return ErrSketchMismatch
+	}
+	for i, r := range o.regs {
+		h.regs[i] = max(h.regs[i], r)
+	}
+	return nil
+}
+
+// hllVersion is the version of the binary encoding.
+const hllVersion = 1
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is a version byte, the precision
+// byte and then the 2^p registers, one byte each.
+func (h *HyperLogLog) MarshalBinary() ([]byte, error) {
+	return append([]byte{hllVersion, h.p}, h.regs...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (h *HyperLogLog) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 || data[0] != hllVersion || data[1] < 4 || data[1] > 16 || len(data) != 2+1<<data[1] {
+		return ErrBadEncoding
+	}
+	h.p = data[1]
+	h.regs = slices.Clone(data[2:])
+	return nil
+}
+
+// Copy makes a copy of the sketch.
+func (h *HyperLogLog) Copy() *HyperLogLog {
+	return &HyperLogLog{h.p, slices.Clone(h.regs)}
+}
@@ -304,11 +304,11 @@ func (t *KDTree) planeDist(pt, cur []float64, axis int) float64 {
 }
 
 func kthDistance(pq *PriorityList, k int) float64 {
-	q := pq.Slice()
-	if len(q) <= k {
+	itm, ok := pq.At(k)
+	if !ok {
 		return math.MaxFloat64
 	}
-	return q[k].Priority
+	return itm.Priority
 }
 
 type KDNode struct {
@@ -0,0 +1,19 @@
+package datastruct
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func BenchmarkKDTreeKNN(b *testing.B) {
+	pts := make([][]float64, 200000)
+	for i := range pts {
+		pts[i] = []float64{rand.Float64(), rand.Float64()}
+	}
+	t := NewKDTree(2, pts...)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		t.KNN([]float64{rand.Float64(), rand.Float64()}, 50)
+	}
+}
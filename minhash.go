@@ -0,0 +1,125 @@
+package datastruct
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"slices"
+)
+
+var (
+	// ErrSketchMismatch is returned when sketches built with different parameters are combined
+	ErrSketchMismatch = errors.New("Sketches aren't compatible")
+)
+
+// MinHash is a signature of a set of integers, from which the Jaccard similarity of two sets can
+// be estimated without the sets themselves. The signature holds the minimum value of each of k hash
+// functions over the set's elements; the fraction of positions where two signatures agree estimates
+// the similarity, with a standard error of about 1/sqrt(k). Signatures computed with the same k and
+// seed, in any process, can be compared and merged.
+type MinHash struct {
+	seed uint64
+	mins []uint64
+}
+
+// NewMinHash creates a new, empty, signature with k hash functions derived from seed.
+func NewMinHash(k int, seed uint64) *MinHash {
+	if k < 1 {
+		k = 1
+	}
+	mins := make([]uint64, k)
+	for i := range mins {
+		mins[i] = math.MaxUint64
+	}
+	return &MinHash{seed, mins}
+}
+
+// Add adds the element e to the signature.
+func (m *MinHash) Add(e int) {
+	for i := range m.mins {
+		if h := mix64(uint64(e) ^ mix64(m.seed+uint64(i))); h < m.mins[i] {
+			m.mins[i] = h
+		}
+	}
+}
+
+// AddSet adds the elements of s to the signature.
+func (m *MinHash) AddSet(s Set) {
+	for e, v := range s {
+		if v {
+			m.Add(e)
+		}
+	}
+}
+
+// Similarity returns the estimated Jaccard similarity of the sets that made the signatures.
+func (m *MinHash) Similarity(o *MinHash) (float64, error) {
+	if m.seed != o.seed || len(m.mins) != len(o.mins) {
+		return 0, ErrSketchMismatch
+	}
+	n := 0
+	for i, v := range m.mins {
+		if v == o.mins[i] {
+			n++
+		}
+	}
+	return float64(n) / float64(len(m.mins)), nil
+}
+
+// Merge combines o into the signature, so that it becomes the signature of the union of the sets.
+func (m *MinHash) Merge(o *MinHash) error {
+	if m.seed != o.seed || len(m.mins) != len(o.mins) {
+		return ErrSketchMismatch
+	}
+	for i, v := range o.mins {
+		m.mins[i] = min(m.mins[i], v)
+	}
+	return nil
+}
+
+// mhVersion is the version of the binary encoding.
+const mhVersion = 1
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is a version byte, k as a
+// uvarint, then the seed and the k minimums as little endian uint64s.
+func (m *MinHash) MarshalBinary() ([]byte, error) {
+	res := []byte{mhVersion}
+	res = binary.AppendUvarint(res, uint64(len(m.mins)))
+	res = binary.LittleEndian.AppendUint64(res, m.seed)
+	for _, v := range m.mins {
+		res = binary.LittleEndian.AppendUint64(res, v)
+	}
+	return res, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (m *MinHash) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != mhVersion {
+		return ErrBadEncoding
+	}
+	k, l := binary.Uvarint(data[1:])
+	data = data[1+max(l, 0):]
+	if l <= 0 || k < 1 || len(data) < 16 || k > uint64(len(data))/8-1 || uint64(len(data)) != 8*(k+1) {
+		return ErrBadEncoding
+	}
+	m.seed = binary.LittleEndian.Uint64(data)
+	m.mins = make([]uint64, k)
+	for i := range m.mins {
+		m.mins[i] = binary.LittleEndian.Uint64(data[8*(i+1):])
+	}
+	return nil
+}
+
+// Copy makes a copy of the signature.
+func (m *MinHash) Copy() *MinHash {
+	return &MinHash{m.seed, slices.Clone(m.mins)}
+}
+
+// mix64 is the splitmix64 finalizer. It's used by the sketches, rather than hash/maphash, so that
+// hashes are the same in every process.
+func mix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
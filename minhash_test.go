@@ -0,0 +1,34 @@
+package datastruct
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func TestMinHashUnmarshalBadLength(t *testing.T) {
+	// A k whose byte size wraps must not pass validation
+	for _, n := range []int{0, 8} {
+		data := []byte{mhVersion}
+		data = binary.AppendUvarint(data, 1<<61-1)
+		data = append(data, make([]byte, n)...)
+		var m MinHash
+		if err := m.UnmarshalBinary(data); !errors.Is(err, ErrBadEncoding) {
+			t.Fatalf("got %v, want ErrBadEncoding", err)
+		}
+	}
+
+	h := NewMinHash(16, 1)
+	h.Add(1)
+	good, _ := h.MarshalBinary()
+	var m MinHash
+	if err := m.UnmarshalBinary(good); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := m.Similarity(h); s != 1 {
+		t.Fatalf("got similarity %v, want 1", s)
+	}
+	if err := m.UnmarshalBinary(good[:len(good)-1]); !errors.Is(err, ErrBadEncoding) {
+		t.Fatalf("got %v, want ErrBadEncoding", err)
+	}
+}
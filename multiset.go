@@ -0,0 +1,224 @@
+package datastruct
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// Multiset represents a bag of elements, where each element has a count of how many times it
+// occurs. Elements with a count of zero aren't held.
+type Multiset[T comparable] map[T]int
+
+// ElementCount holds an element and its count.
+type ElementCount[T comparable] struct {
+	Element T
+	Count   int
+}
+
+// NewMultiset returns a new multiset with the provided elements in it. Repeated elements are
+// counted.
+func NewMultiset[T comparable](elts ...T) Multiset[T] {
+	res := make(Multiset[T])
+	for _, e := range elts {
+		res[e]++
+	}
+	return res
+}
+
+// MultisetFromSet returns a new multiset containing the elements of s, which can be a Set or
+// SetOf, each with a count of one.
+func MultisetFromSet[S ~map[T]bool, T comparable](s S) Multiset[T] {
+	res := make(Multiset[T])
+	for e, v := range s {
+		if v {
+			res[e] = 1
+		}
+	}
+	return res
+}
+
+// ToSet returns a new set containing the distinct elements of the multiset.
+func (m Multiset[T]) ToSet() SetOf[T] {
+	res := make(SetOf[T], len(m))
+	for e := range m {
+		res[e] = true
+	}
+	return res
+}
+
+// Add adds n occurrences of e to the multiset and returns the new count for e. Adding a
+// non-positive number of occurrences is a no-op.
+func (m Multiset[T]) Add(e T, n int) int {
+	if n > 0 {
+		m[e] += n
+	}
+	return m[e]
+}
+
+// Remove removes up to n occurrences of e from the multiset and returns the new count for e.
+// Removing a non-positive number of occurrences is a no-op.
+func (m Multiset[T]) Remove(e T, n int) int {
+	if n <= 0 {
+		return m[e]
+	}
+	c := m[e] - n
+	if c <= 0 {
+		delete(m, e)
+		return 0
+	}
+	m[e] = c
+	return c
+}
+
+// Count returns the number of occurrences of e.
+func (m Multiset[T]) Count(e T) int {
+	return m[e]
+}
+
+// Element returns true if the multiset contains at least one occurrence of e.
+func (m Multiset[T]) Element(e T) bool {
+	return m[e] > 0
+}
+
+// Empty returns true if the multiset is empty.
+func (m Multiset[T]) Empty() bool {
+	return len(m) == 0
+}
+
+// Len returns the total number of occurrences of all the elements in the multiset.
+func (m Multiset[T]) Len() int {
+	n := 0
+	for _, c := range m {
+		n += c
+	}
+	return n
+}
+
+// Distinct returns the number of distinct elements in the multiset.
+func (m Multiset[T]) Distinct() int {
+	return len(m)
+}
+
+// Copy makes a copy of the multiset.
+func (m Multiset[T]) Copy() Multiset[T] {
+	res := make(Multiset[T], len(m))
+	for e, c := range m {
+		res[e] = c
+	}
+	return res
+}
+
+// Union returns a new multiset where each element's count is the maximum of its counts in the
+// multiset and b.
+func (m Multiset[T]) Union(b Multiset[T]) Multiset[T] {
+	res := m.Copy()
+	for e, c := range b {
+		res[e] = max(res[e], c)
+	}
+	return res
+}
+
+// Sum returns a new multiset where each element's count is the sum of its counts in the multiset
+// and b.
+func (m Multiset[T]) Sum(b Multiset[T]) Multiset[T] {
+	res := m.Copy()
+	for e, c := range b {
+		res[e] += c
+	}
+	return res
+}
+
+// Intersection returns a new multiset where each element's count is the minimum of its counts in
+// the multiset and b.
+func (m Multiset[T]) Intersection(b Multiset[T]) Multiset[T] {
+	small, large := m, b
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	res := make(Multiset[T])
+	for e, c := range small {
+		if n := min(c, large[e]); n > 0 {
+			res[e] = n
+		}
+	}
+	return res
+}
+
+// Sub returns a new multiset where each element's count in b is subtracted from its count in the
+// multiset, stopping at zero (the bag difference).
+func (m Multiset[T]) Sub(b Multiset[T]) Multiset[T] {
+	res := make(Multiset[T])
+	for e, c := range m {
+		if n := c - b[e]; n > 0 {
+			res[e] = n
+		}
+	}
+	return res
+}
+
+// Difference returns a new multiset where each element's count is the absolute difference between
+// its counts in the multiset and b.
+func (m Multiset[T]) Difference(b Multiset[T]) Multiset[T] {
+	res := m.Sub(b)
+	for e, c := range b {
+		if n := c - m[e]; n > 0 {
+			res[e] = n
+		}
+	}
+	return res
+}
+
+// Contains returns true if every element in b occurs at least as many times in the multiset.
+func (m Multiset[T]) Contains(b Multiset[T]) bool {
+	for e, c := range b {
+		if m[e] < c {
+			return false
+		}
+	}
+	return true
+}
+
+// MostCommon returns the k elements with the highest counts, highest first. The order of elements
+// with the same count is undefined. If k is negative, or more than the number of distinct
+// elements, all of the elements are returned.
+func (m Multiset[T]) MostCommon(k int) []ElementCount[T] {
+	res := make([]ElementCount[T], 0, len(m))
+	for e, c := range m {
+		res = append(res, ElementCount[T]{e, c})
+	}
+	slices.SortFunc(res, func(a, b ElementCount[T]) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+	if k >= 0 && k < len(res) {
+		res = res[:k]
+	}
+	return res
+}
+
+// String returns a string representation of the multiset with each element's count.
+func (m Multiset[T]) String() string {
+	res := "{"
+	first := true
+	for e, c := range m {
+		if first {
+			res += fmt.Sprintf("%v: %d", e, c)
+			first = false
+		} else {
+			res += fmt.Sprintf(", %v: %d", e, c)
+		}
+	}
+	return res + "}"
+}
+
+// Slice returns an unsorted slice of the elements in the multiset, with each element repeated
+// according to its count.
+func (m Multiset[T]) Slice() []T {
+	res := make([]T, 0, m.Len())
+	for e, c := range m {
+		for i := 0; i < c; i++ {
+			res = append(res, e)
+		}
+	}
+	return res
+}
@@ -0,0 +1,159 @@
+package datastruct
+
+// PairingHeap is an integer id based priority queue with the same API as PriorityQueue, that can
+// also be melded with another PairingHeap in O(1) (plus the cost of merging the id maps).
+// Decreasing an id's priority is O(1), popping is O(log n) amortized.
+type PairingHeap struct {
+	id2node map[int]*phnode
+	root    *phnode
+}
+
+// phnode is a node in the heap. Children are held in a doubly linked list; prev is the parent for
+// the first child, and the previous sibling otherwise.
+type phnode struct {
+	id       int
+	priority float64
+	child    *phnode
+	sibling  *phnode
+	prev     *phnode
+}
+
+// NewPairingHeap creates a new heap instance
+func NewPairingHeap() *PairingHeap {
+	return &PairingHeap{make(map[int]*phnode), nil}
+}
+
+// Len returns the number of entries in the heap
+func (h *PairingHeap) Len() int {
+	return len(h.id2node)
+}
+
+// Insert a new id with priority or change the priority of an existing id
+func (h *PairingHeap) Insert(id int, pri float64) {
+	n, ok := h.id2node[id]
+	if !ok {
+		// New node
+		n = &phnode{id: id, priority: pri}
+		h.id2node[id] = n
+		h.root = phmeld(h.root, n)
+		return
+	}
+
+	if pri < n.priority {
+		// Decrease key - cut the node's subtree out and meld it with the root
+		n.priority = pri
+		if n != h.root {
+			n.detach()
+			h.root = phmeld(h.root, n)
+		}
+		return
+	}
+
+	// Increase key - remove the node from the heap and reinsert it
+	if n == h.root {
+		h.root = phmergePairs(n.child)
+	} else {
+		n.detach()
+		h.root = phmeld(h.root, phmergePairs(n.child))
+	}
+	n.child = nil
+	n.priority = pri
+	h.root = phmeld(h.root, n)
+}
+
+// Pop returns the lowest priority id and removes it from the heap
+func (h *PairingHeap) Pop() (int, error) {
+	if h.root == nil {
+		return 0, ErrEmpty
+	}
+	n := h.root
+	h.root = phmergePairs(n.child)
+	delete(h.id2node, n.id)
+	return n.id, nil
+}
+
+// Meld moves all the entries in other into the heap, leaving other empty. If the heaps share
+// an id then ErrDuplicateId is returned and neither heap is modified.
+func (h *PairingHeap) Meld(other *PairingHeap) error {
+	if h == other {
+		return nil
+	}
+	small, large := other.id2node, h.id2node
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	for id := range small {
+		if _, ok := large[id]; ok {
+			return ErrDuplicateId
+		}
+	}
+	for id, n := range small {
+		large[id] = n
+	}
+	h.id2node = large
+	h.root = phmeld(h.root, other.root)
+	other.id2node = make(map[int]*phnode)
+	other.root = nil
+	return nil
+}
+
+// detach removes the node, and its subtree, from its parent's child list.
+func (n *phnode) detach() {
+	if n.prev.child == n {
+		n.prev.child = n.sibling
+	} else {
+		n.prev.sibling = n.sibling
+	}
+	if n.sibling != nil {
+		n.sibling.prev = n.prev
+	}
+	n.prev = nil
+	n.sibling = nil
+}
+
+// phmeld combines two heap roots and returns the new root.
+func phmeld(a, b *phnode) *phnode {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if b.priority < a.priority {
+		a, b = b, a
+	}
+	// b becomes the first child of a
+	b.prev = a
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	return a
+}
+
+// phmergePairs performs the two pass pairing of a child list and returns the new root.
+func phmergePairs(first *phnode) *phnode {
+	if first == nil {
+		return nil
+	}
+	// Pass 1 - meld pairs left to right
+	pairs := []*phnode{}
+	for a := first; a != nil; {
+		b := a.sibling
+		var next *phnode
+		if b != nil {
+			next = b.sibling
+			b.prev, b.sibling = nil, nil
+		}
+		a.prev, a.sibling = nil, nil
+		pairs = append(pairs, phmeld(a, b))
+		a = next
+	}
+	// Pass 2 - meld right to left
+	res := pairs[len(pairs)-1]
+	for i := len(pairs) - 2; i >= 0; i-- {
+		res = phmeld(pairs[i], res)
+	}
+	return res
+}
@@ -0,0 +1,361 @@
+package datastruct
+
+import (
+	"fmt"
+	"hash/maphash"
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+// psSeed is shared by all persistent sets so sets can be compared structurally.
+var psSeed = maphash.MakeSeed()
+
+// PersistentSet represents an immutable set of comparable elements. Add and Remove return a new
+// set which shares all but O(log n) of its structure with the original, so keeping many versions
+// of a set is cheap. The set is a hash array mapped trie (HAMT). The zero value is an empty set.
+type PersistentSet[T comparable] struct {
+	root *psnode[T]
+	n    int
+	sum  uint64 // sum of the element hashes, for a quick inequality check
+}
+
+// psnode is a node in the trie. There is an entry for each bit set in bitmap, in bit order.
+type psnode[T comparable] struct {
+	bitmap  uint32
+	entries []psentry[T]
+}
+
+// psentry is either a child node, or a leaf holding the elements with a particular hash (more than
+// one only on a full hash collision).
+type psentry[T comparable] struct {
+	node *psnode[T]
+	hash uint64
+	elts []T
+}
+
+const psBits = 5
+
+// NewPersistentSet returns a new set with the provided elements in it.
+func NewPersistentSet[T comparable](elts ...T) PersistentSet[T] {
+	var res PersistentSet[T]
+	for _, e := range elts {
+		res = res.Add(e)
+	}
+	return res
+}
+
+// Add returns a set with the element e added. If e is already in the set, the set is returned.
+func (s PersistentSet[T]) Add(e T) PersistentSet[T] {
+	h := maphash.Comparable(psSeed, e)
+	root, ok := s.root.add(e, h, 0)
+	if !ok {
+		return s
+	}
+	return PersistentSet[T]{root, s.n + 1, s.sum + h}
+}
+
+// Remove returns a set with the element e removed. If e isn't in the set, the set is returned.
+func (s PersistentSet[T]) Remove(e T) PersistentSet[T] {
+	h := maphash.Comparable(psSeed, e)
+	root, ok := s.root.remove(e, h, 0)
+	if !ok {
+		return s
+	}
+	return PersistentSet[T]{root, s.n - 1, s.sum - h}
+}
+
+// Element returns true if the set contains the element e.
+func (s PersistentSet[T]) Element(e T) bool {
+	h := maphash.Comparable(psSeed, e)
+	n := s.root
+	for shift := 0; n != nil; shift += psBits {
+		bit := uint32(1) << ((h >> shift) & 31)
+		if n.bitmap&bit == 0 {
+			return false
+		}
+		ent := &n.entries[bits.OnesCount32(n.bitmap&(bit-1))]
+		if ent.node == nil {
+			return ent.hash == h && slices.Contains(ent.elts, e)
+		}
+		n = ent.node
+	}
+	return false
+}
+
+// Empty returns true if the set is the empty set.
+func (s PersistentSet[T]) Empty() bool {
+	return s.n == 0
+}
+
+// Len returns the number of elements in the set.
+func (s PersistentSet[T]) Len() int {
+	return s.n
+}
+
+// Equal returns true if the set and b contain the same elements. Sets that differ in size or
+// content are usually rejected in O(1), and shared structure isn't compared.
+func (s PersistentSet[T]) Equal(b PersistentSet[T]) bool {
+	if s.n != b.n || s.sum != b.sum {
+		return false
+	}
+	return s.root.equal(b.root)
+}
+
+// Union returns a new set containing the union of the set and b (OR).
+func (s PersistentSet[T]) Union(b PersistentSet[T]) PersistentSet[T] {
+	small, large := b, s
+	if small.n > large.n {
+		small, large = large, small
+	}
+	for e := range small.All() {
+		large = large.Add(e)
+	}
+	return large
+}
+
+// Intersection returns a new set containing the intersection of the set and b (AND).
+func (s PersistentSet[T]) Intersection(b PersistentSet[T]) PersistentSet[T] {
+	small, large := b, s
+	if small.n > large.n {
+		small, large = large, small
+	}
+	res := small
+	for e := range small.All() {
+		if !large.Element(e) {
+			res = res.Remove(e)
+		}
+	}
+	return res
+}
+
+// Difference returns a new set containing only the elements in either the set or b but not in both (XOR).
+func (s PersistentSet[T]) Difference(b PersistentSet[T]) PersistentSet[T] {
+	res := s
+	for e := range b.All() {
+		if s.Element(e) {
+			res = res.Remove(e)
+		} else {
+			res = res.Add(e)
+		}
+	}
+	return res
+}
+
+// Sub returns a new set containing the elements in the set which are not in b (SUB).
+func (s PersistentSet[T]) Sub(b PersistentSet[T]) PersistentSet[T] {
+	res := s
+	if b.n < s.n {
+		for e := range b.All() {
+			res = res.Remove(e)
+		}
+		return res
+	}
+	for e := range s.All() {
+		if b.Element(e) {
+			res = res.Remove(e)
+		}
+	}
+	return res
+}
+
+// Contains returns true if b is completely contained in the set.
+func (s PersistentSet[T]) Contains(b PersistentSet[T]) bool {
+	if b.n > s.n {
+		return false
+	}
+	for e := range b.All() {
+		if !s.Element(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// Disjoint returns true if the set and b share no elements in common.
+func (s PersistentSet[T]) Disjoint(b PersistentSet[T]) bool {
+	small, large := b, s
+	if small.n > large.n {
+		small, large = large, small
+	}
+	for e := range small.All() {
+		if large.Element(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// All returns an iterator over the elements of the set in no particular order.
+func (s PersistentSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.root.all(yield)
+	}
+}
+
+// Slice returns an unsorted slice representation of the set.
+func (s PersistentSet[T]) Slice() []T {
+	res := make([]T, 0, s.n)
+	for e := range s.All() {
+		res = append(res, e)
+	}
+	return res
+}
+
+// String returns a string representation of the set.
+func (s PersistentSet[T]) String() string {
+	res := "{"
+	first := true
+	for e := range s.All() {
+		if first {
+			res += fmt.Sprintf("%v", e)
+			first = false
+		} else {
+			res += fmt.Sprintf(", %v", e)
+		}
+	}
+	return res + "}"
+}
+
+// add returns a copy of the path to e with e added, or n and false if e is already present.
+func (n *psnode[T]) add(e T, h uint64, shift int) (*psnode[T], bool) {
+	if n == nil {
+		n = &psnode[T]{}
+	}
+	bit := uint32(1) << ((h >> shift) & 31)
+	pos := bits.OnesCount32(n.bitmap & (bit - 1))
+	if n.bitmap&bit == 0 {
+		res := &psnode[T]{n.bitmap | bit, slices.Insert(slices.Clone(n.entries), pos, psentry[T]{hash: h, elts: []T{e}})}
+		return res, true
+	}
+
+	ent := n.entries[pos]
+	switch {
+	case ent.node != nil:
+		child, ok := ent.node.add(e, h, shift+psBits)
+		if !ok {
+			return n, false
+		}
+		ent = psentry[T]{node: child}
+	case ent.hash == h:
+		if slices.Contains(ent.elts, e) {
+			return n, false
+		}
+		// Full hash collision
+		ent = psentry[T]{hash: h, elts: append(slices.Clone(ent.elts), e)}
+	default:
+		// Push the existing leaf down into a new node along with e
+		child := &psnode[T]{}
+		child = child.addEntry(ent, shift+psBits)
+		child, _ = child.add(e, h, shift+psBits)
+		ent = psentry[T]{node: child}
+	}
+	res := &psnode[T]{n.bitmap, slices.Clone(n.entries)}
+	res.entries[pos] = ent
+	return res, true
+}
+
+// addEntry adds the leaf ent to the new node n.
+func (n *psnode[T]) addEntry(ent psentry[T], shift int) *psnode[T] {
+	bit := uint32(1) << ((ent.hash >> shift) & 31)
+	n.bitmap |= bit
+	n.entries = append(n.entries, ent)
+	return n
+}
+
+// remove returns a copy of the path to e with e removed, or n and false if e isn't present.
+// Nodes left with a single leaf are collapsed into their parent, which keeps the trie's shape
+// dependent only on its contents.
+func (n *psnode[T]) remove(e T, h uint64, shift int) (*psnode[T], bool) {
+	if n == nil {
+		return nil, false
+	}
+	bit := uint32(1) << ((h >> shift) & 31)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	pos := bits.OnesCount32(n.bitmap & (bit - 1))
+
+	ent := n.entries[pos]
+	if ent.node != nil {
+		child, ok := ent.node.remove(e, h, shift+psBits)
+		if !ok {
+			return n, false
+		}
+		if len(child.entries) == 1 && child.entries[0].node == nil {
+			// Collapse
+			ent = child.entries[0]
+		} else {
+			ent = psentry[T]{node: child}
+		}
+	} else {
+		i := slices.Index(ent.elts, e)
+		if ent.hash != h || i == -1 {
+			return n, false
+		}
+		if len(ent.elts) == 1 {
+			return &psnode[T]{n.bitmap &^ bit, slices.Delete(slices.Clone(n.entries), pos, pos+1)}, true
+		}
+		ent = psentry[T]{hash: h, elts: slices.Delete(slices.Clone(ent.elts), i, i+1)}
+	}
+	res := &psnode[T]{n.bitmap, slices.Clone(n.entries)}
+	res.entries[pos] = ent
+	return res, true
+}
+
+func (n *psnode[T]) equal(o *psnode[T]) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return n.empty() && o.empty()
+	}
+	if n.bitmap != o.bitmap {
+		return false
+	}
+	for i, a := range n.entries {
+		b := o.entries[i]
+		switch {
+		case a.node != nil && b.node != nil:
+			if !a.node.equal(b.node) {
+				return false
+			}
+		case a.node == nil && b.node == nil:
+			if a.hash != b.hash || len(a.elts) != len(b.elts) {
+				return false
+			}
+			for _, e := range a.elts {
+				if !slices.Contains(b.elts, e) {
+					return false
+				}
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (n *psnode[T]) empty() bool {
+	return n == nil || len(n.entries) == 0
+}
+
+func (n *psnode[T]) all(yield func(T) bool) bool {
+	if n == nil {
+		return true
+	}
+	for _, ent := range n.entries {
+		if ent.node != nil {
+			if !ent.node.all(yield) {
+				return false
+			}
+			continue
+		}
+		for _, e := range ent.elts {
+			if !yield(e) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -1,5 +1,10 @@
 package datastruct
 
+import (
+	"math/bits"
+	"math/rand/v2"
+)
+
 // PriorityItem contains the priority value and an id.
 type PriorityItem struct {
 	Priority float64
@@ -12,8 +17,37 @@ func NewPriorityItem(pri float64, id int) PriorityItem {
 }
 
 // PriorityList holds the prioritized list of items. The lower the priority, the closer to the start
-// of the list the item is.
-type PriorityList []PriorityItem
+// of the list the item is. Items of equal priority are kept in insertion order.
+//
+// The list is backed by an indexable skip list so Insert, Pop, DeleteEntry and Where are all
+// O(log n) rather than O(n). Once UpdatePriority or DeleteId has been called, the list also tracks
+// the location of each id so they are O(1) to find the item and O(log n) overall. Ids are expected
+// to be unique; if an id is inserted more than once, the most recent insertion is the one tracked.
+type PriorityList struct {
+	head  *plnode // sentinel, has plMaxLevel levels
+	level int     // number of levels in use
+	n     int
+	seq   uint64          // insertion counter, orders items of equal priority
+	ids   map[int]*plnode // id to node, nil until first needed
+	dups  int             // number of nodes not in ids
+}
+
+const plMaxLevel = 32
+
+// plnode is a skip list node. link[i].width is the number of level 0 steps from this node to
+// link[i].next.
+type plnode struct {
+	item  PriorityItem
+	seq   uint64
+	link  []pllink
+	small [2]pllink // backs link for the 15/16 of nodes with one or two levels
+}
+
+// pllink is a forward link in a skip list node.
+type pllink struct {
+	next  *plnode
+	width int
+}
 
 // NewPriorityList creates a new PriorityList with the items inserted. Lower values are inserted
 // before higher ones.
@@ -25,156 +59,290 @@ func NewPriorityList(items ...PriorityItem) *PriorityList {
 	return res
 }
 
-// Slice returns the priority item slice
+func (pq *PriorityList) init() {
+	pq.head = &plnode{link: make([]pllink, plMaxLevel)}
+	pq.level = 1
+}
+
+// Len returns the number of items in the list.
+func (pq *PriorityList) Len() int {
+	return pq.n
+}
+
+// Slice returns a copy of the list as a priority item slice. This is O(n).
 func (pq *PriorityList) Slice() []PriorityItem {
-	return (*pq)[:]
+	res := make([]PriorityItem, 0, pq.n)
+	if pq.head == nil {
+		return res
+	}
+	for x := pq.head.link[0].next; x != nil; x = x.link[0].next {
+		itm := x.item
+		itm.index = len(res)
+		res = append(res, itm)
+	}
+	return res
 }
 
+// At returns the item at location i in the list. If i is out of range then false is returned.
+// This is O(log n).
+func (pq *PriorityList) At(i int) (PriorityItem, bool) {
+	if i < 0 || i >= pq.n {
+		return PriorityItem{index: -1}, false
+	}
+	itm := pq.find(i + 1)[0].link[0].next.item
+	itm.index = i
+	return itm, true
+}
+
+// Pop removes and returns the first (lowest priority) item in the list. If the list is empty,
+// the returned item has an index of -1.
 func (pq *PriorityList) Pop() PriorityItem {
-	pi := (*pq)[0]
-	*pq = (*pq)[1:]
-	for i := range *pq {
-		//(*pq)[i].index--
-		(*pq)[i].index = i
+	if pq.n == 0 {
+		return PriorityItem{index: -1}
 	}
-	return pi
+	x := pq.remove(pq.find(1))
+	x.item.index = -1
+	return x.item
 }
 
 // Insert inserts the item into the list at the correct point and returns that insertion point.
-// Insertion is performed using a binary search and copy() for speed.
+// Items are inserted after any existing items of the same priority.
 func (pq *PriorityList) Insert(v PriorityItem) int {
-	if len(*pq) == 0 {
-		// First entry
-		*pq = append(*pq, v)
-		return 0
+	if pq.head == nil {
+		pq.init()
 	}
-	n := len(*pq)
-	res := pq.helper(v.Priority, 0, n-1, false)
-	v.index = res
-	if res == n {
-		// Insert at end
-		*pq = append(*pq, v)
-		return res
+	var update [plMaxLevel]*plnode
+	var rank [plMaxLevel]int
+	x := pq.head
+	for i := pq.level - 1; i >= 0; i-- {
+		if i < pq.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.link[i].next != nil && x.link[i].next.item.Priority <= v.Priority {
+			rank[i] += x.link[i].width
+			x = x.link[i].next
+		}
+		update[i] = x
 	}
-	// Insert at res
-	*pq = append(*pq, PriorityItem{})
-	copy((*pq)[res+1:], (*pq)[res:]) // copy down
-	(*pq)[res] = v
-	for i := res + 1; i < n+1; i++ {
-		//(*pq)[i].index++
-		(*pq)[i].index = i
+
+	lvl := plRandomLevel()
+	if lvl > pq.level {
+		for i := pq.level; i < lvl; i++ {
+			update[i] = pq.head
+			pq.head.link[i].width = pq.n
+		}
+		pq.level = lvl
 	}
-	return res
+
+	v.index = rank[0]
+	node := &plnode{item: v, seq: pq.seq}
+	if lvl <= len(node.small) {
+		node.link = node.small[:lvl]
+	} else {
+		node.link = make([]pllink, lvl)
+	}
+	pq.seq++
+	if pq.ids != nil {
+		if _, ok := pq.ids[v.Id]; ok {
+			pq.dups++
+		}
+		pq.ids[v.Id] = node
+	}
+	for i := 0; i < lvl; i++ {
+		node.link[i].next = update[i].link[i].next
+		update[i].link[i].next = node
+		node.link[i].width = update[i].link[i].width - (rank[0] - rank[i])
+		update[i].link[i].width = rank[0] - rank[i] + 1
+	}
+	for i := lvl; i < pq.level; i++ {
+		update[i].link[i].width++
+	}
+	pq.n++
+	return rank[0]
 }
 
-// ChangedPriority must be called for any item that changes priority.
-// The new location is returned.
+// ChangedPriority must be called for any item that changes priority. If the item's id isn't in
+// the list then the item is inserted. The new location is returned.
 func (pq *PriorityList) ChangedPriority(v PriorityItem) int {
-	if v.index != -1 {
-		pq.DeleteEntry(v.index)
+	if res := pq.UpdatePriority(v.Id, v.Priority); res != -1 {
+		return res
 	}
 	return pq.Insert(v)
 }
 
-// Delete removes the entry in the list with the item (if found) and returns true. If the item isn't
-// then false is returned.
-func (pq *PriorityList) Delete(v PriorityItem) bool {
-	if v.index == -1 {
-		return false
+// UpdatePriority changes the priority of the item with the id and returns its new location. If the
+// id isn't in the list then -1 is returned.
+func (pq *PriorityList) UpdatePriority(id int, pri float64) int {
+	x := pq.node(id)
+	if x == nil {
+		return -1
 	}
-	if (*pq)[v.index].Id != v.Id {
-		// CYA
-		return pq.DeleteId(v.Id)
-	}
-	return pq.DeleteEntry(v.index)
+	pq.unlink(x)
+	v := x.item
+	v.Priority = pri
+	return pq.Insert(v)
+}
+
+// Delete removes the entry in the list with the item's id (if found) and returns true. If the item
+// isn't then false is returned.
+func (pq *PriorityList) Delete(v PriorityItem) bool {
+	return pq.DeleteId(v.Id)
 }
 
 // DeleteId removes the entry in the list with the item id (if found) and returns true. If the id
-// isn't found then false is returned. This function uses a linear scan to find the id.
+// isn't found then false is returned.
 func (pq *PriorityList) DeleteId(id int) bool {
-	for i := 0; i < len(*pq); i++ {
-		if (*pq)[i].Id == id {
-			return pq.DeleteEntry(i)
-		}
+	x := pq.node(id)
+	if x == nil {
+		return false
 	}
-	return false
+	pq.unlink(x)
+	return true
 }
 
-// DeleteEntry removes an entry from the list, compacts it and returns true. If the entry is not in range
+// DeleteEntry removes an entry from the list and returns true. If the entry is not in range
 // then false is returned.
 func (pq *PriorityList) DeleteEntry(e int) bool {
-	n := len(*pq)
-	if e > n-1 {
+	if e < 0 || e > pq.n-1 {
 		return false
 	}
-	if e == 0 {
-		*pq = (*pq)[1:]
-		for i := range *pq {
-			//(*pq)[i].index--
-			(*pq)[i].index = i
-		}
-		return true
-	}
-	if e < n-1 {
-		copy((*pq)[e:], (*pq)[e+1:]) // copy up
+	pq.remove(pq.find(e + 1))
+	return true
+}
+
+// Where returns where an item of priority pri would be inserted. The bool left indicates
+// for priorities of the same value whether the new one should be inserted to the left or
+// right of the current ones.
+func (pq *PriorityList) Where(v float64, left bool) int {
+	if pq.head == nil {
+		return 0
 	}
-	for i := e + 1; i < n-1; i++ {
-		//(*pq)[i].index--
-		(*pq)[i].index = i
+	rank := 0
+	x := pq.head
+	for i := pq.level - 1; i >= 0; i-- {
+		for x.link[i].next != nil && (x.link[i].next.item.Priority < v || (!left && x.link[i].next.item.Priority == v)) {
+			rank += x.link[i].width
+			x = x.link[i].next
+		}
 	}
-	*pq = (*pq)[:n-1] // shrink
-	return true
+	return rank
 }
 
-// Recursive binary search function.
-func (pq *PriorityList) helper(v float64, s, e int, left bool) int {
-	if v < (*pq)[s].Priority {
-		// Before s
-		return s
+// node returns the node for id or nil. Only if the id has been inserted more than once is a
+// linear scan required.
+func (pq *PriorityList) node(id int) *plnode {
+	if pq.head == nil {
+		return nil
+	}
+	if pq.ids == nil {
+		pq.index()
+	}
+	if x, ok := pq.ids[id]; ok {
+		return x
+	}
+	if pq.dups == 0 {
+		return nil
 	}
-	if v > (*pq)[e].Priority {
-		// After e
-		return e + 1
+	for x := pq.head.link[0].next; x != nil; x = x.link[0].next {
+		if x.item.Id == id {
+			// Track it from now on
+			pq.ids[id] = x
+			pq.dups--
+			return x
+		}
 	}
+	return nil
+}
 
-	if left {
-		if e-s == 1 {
-			if v > (*pq)[s].Priority {
-				return e
-			} else {
-				return s
+// index builds ids from the nodes in the list.
+func (pq *PriorityList) index() {
+	pq.ids = make(map[int]*plnode, pq.n)
+	pq.dups = 0
+	for x := pq.head.link[0].next; x != nil; x = x.link[0].next {
+		if y, ok := pq.ids[x.item.Id]; ok {
+			pq.dups++
+			if y.seq > x.seq {
+				continue
 			}
 		}
-		d := (e - s + 1) / 2
-		if d == 0 {
-			return s
+		pq.ids[x.item.Id] = x
+	}
+}
+
+// unlink removes the node x from the list. x is found by its priority, falling back to a scan if
+// that fails, as it does for NaN priorities.
+func (pq *PriorityList) unlink(x *plnode) {
+	update := pq.locate(x)
+	if update[0].link[0].next != x {
+		r := 1
+		for y := pq.head.link[0].next; y != x; y = y.link[0].next {
+			r++
 		}
-		if v > (*pq)[e-d].Priority {
-			return pq.helper(v, e-d, e, true)
+		update = pq.find(r)
+	}
+	pq.remove(update)
+}
+
+// locate returns the predecessors at each level of node n.
+func (pq *PriorityList) locate(n *plnode) [plMaxLevel]*plnode {
+	var update [plMaxLevel]*plnode
+	pri, seq := n.item.Priority, n.seq
+	x := pq.head
+	for i := pq.level - 1; i >= 0; i-- {
+		for x.link[i].next != nil && (x.link[i].next.item.Priority < pri || (x.link[i].next.item.Priority == pri && x.link[i].next.seq < seq)) {
+			x = x.link[i].next
 		}
-		return pq.helper(v, s, e-d, true)
+		update[i] = x
 	}
+	return update
+}
 
-	if e-s == 1 {
-		if v < (*pq)[e].Priority {
-			return e
+// find returns the predecessors at each level of the node at 1-based position r.
+func (pq *PriorityList) find(r int) [plMaxLevel]*plnode {
+	var update [plMaxLevel]*plnode
+	traversed := 0
+	x := pq.head
+	for i := pq.level - 1; i >= 0; i-- {
+		for x.link[i].next != nil && traversed+x.link[i].width < r {
+			traversed += x.link[i].width
+			x = x.link[i].next
+		}
+		update[i] = x
+	}
+	return update
+}
+
+// remove unlinks the node following update[0] and returns it.
+func (pq *PriorityList) remove(update [plMaxLevel]*plnode) *plnode {
+	x := update[0].link[0].next
+	for i := 0; i < pq.level; i++ {
+		if update[i].link[i].next == x {
+			update[i].link[i].width += x.link[i].width - 1
+			update[i].link[i].next = x.link[i].next
 		} else {
-			return e + 1
+			update[i].link[i].width--
 		}
 	}
-	d := (e - s + 1) / 2
-	if d == 0 {
-		return e + 1
+	for pq.level > 1 && pq.head.link[pq.level-1].next == nil {
+		pq.level--
 	}
-	if v < (*pq)[s+d].Priority {
-		return pq.helper(v, s, s+d, false)
+	pq.n--
+	if pq.ids == nil {
+		return x
 	}
-	return pq.helper(v, e-d, e, false)
+	if pq.ids[x.item.Id] == x {
+		delete(pq.ids, x.item.Id)
+	} else {
+		pq.dups--
+	}
+	return x
 }
 
-// Where returns where an item of priority pri would be inserted. The bool left indicates
-// for priorities of the same value whether the new one should be inserted to the left or
-// right of the current ones.
-func (pq *PriorityList) Where(v float64, left bool) int {
-	return pq.helper(v, 0, len(*pq)-1, left)
+// plRandomLevel returns a level with a 1/4 chance of each additional level.
+func plRandomLevel() int {
+	lvl := 1 + bits.TrailingZeros64(rand.Uint64())/2
+	if lvl > plMaxLevel {
+		lvl = plMaxLevel
+	}
+	return lvl
 }
@@ -0,0 +1,127 @@
+package datastruct
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestPriorityListNaN(t *testing.T) {
+	pl := NewPriorityList()
+	pl.Insert(NewPriorityItem(math.NaN(), 7))
+	pl.Insert(NewPriorityItem(1, 8))
+	pl.Insert(NewPriorityItem(math.NaN(), 9))
+	if !pl.DeleteId(7) {
+		t.Fatal("DeleteId(7) = false")
+	}
+	if pl.UpdatePriority(9, 2) == -1 {
+		t.Fatal("UpdatePriority(9) = -1")
+	}
+	got := pl.Slice()
+	if len(got) != 2 || got[0].Id != 8 || got[1].Id != 9 || got[1].Priority != 2 {
+		t.Fatalf("got %v, want ids 8 and 9", got)
+	}
+	if pl.DeleteId(7) || !pl.DeleteId(8) || !pl.DeleteId(9) || pl.Len() != 0 {
+		t.Fatal("ids out of step with the list")
+	}
+}
+
+func TestPriorityListOrderIdsWhere(t *testing.T) {
+	pris := []float64{5, 1, 3, 1, 4, 3}
+	pl := NewPriorityList()
+	for id, pri := range pris {
+		pl.Insert(NewPriorityItem(pri, id))
+	}
+	// Ascending priority, equal priorities in insertion order
+	want := []int{1, 3, 2, 5, 4, 0}
+	got := pl.Slice()
+	for i, itm := range got {
+		if itm.Id != want[i] {
+			t.Fatalf("Slice() ids %v, want %v", got, want)
+		}
+		if at, ok := pl.At(i); !ok || at.Id != itm.Id {
+			t.Fatalf("At(%d) = %v, %v, want id %d", i, at, ok, itm.Id)
+		}
+	}
+	if _, ok := pl.At(len(pris)); ok {
+		t.Fatal("At past the end succeeded")
+	}
+
+	if w := pl.Where(3, true); w != 2 {
+		t.Fatalf("Where(3, left) = %d, want 2", w)
+	}
+	if w := pl.Where(3, false); w != 4 {
+		t.Fatalf("Where(3, right) = %d, want 4", w)
+	}
+	if w := pl.Where(0, false); w != 0 {
+		t.Fatalf("Where(0, right) = %d, want 0", w)
+	}
+	if w := pl.Where(9, true); w != len(pris) {
+		t.Fatalf("Where(9, left) = %d, want %d", w, len(pris))
+	}
+
+	// Move id 0 to the front, delete id 2 by id and id 1 by position
+	if loc := pl.UpdatePriority(0, 0); loc != 0 {
+		t.Fatalf("UpdatePriority(0, 0) = %d, want 0", loc)
+	}
+	if pl.UpdatePriority(99, 0) != -1 {
+		t.Fatal("UpdatePriority of a missing id succeeded")
+	}
+	if !pl.DeleteId(2) || pl.DeleteId(2) {
+		t.Fatal("DeleteId(2)")
+	}
+	if !pl.DeleteEntry(1) {
+		t.Fatal("DeleteEntry(1)")
+	}
+	want = []int{0, 3, 5, 4}
+	for i := range want {
+		if itm := pl.Pop(); itm.Id != want[i] {
+			t.Fatalf("Pop() %d = id %d, want %d", i, itm.Id, want[i])
+		}
+	}
+	if pl.Len() != 0 || pl.Pop().Id != 0 {
+		t.Fatal("list not empty")
+	}
+}
+
+// Per op times should grow logarithmically with the size of the list.
+func BenchmarkPriorityList(b *testing.B) {
+	for _, n := range []int{1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("InsertPop/n=%d", n), func(b *testing.B) {
+			l := plFill(n)
+			for i := range b.N {
+				// Insert and pop to keep the list size constant
+				l.Insert(NewPriorityItem(rand.Float64(), i))
+				l.Pop()
+			}
+		})
+		b.Run(fmt.Sprintf("InsertDeleteEntry/n=%d", n), func(b *testing.B) {
+			l := plFill(n)
+			for i := range b.N {
+				l.Insert(NewPriorityItem(rand.Float64(), i))
+				l.DeleteEntry(rand.IntN(n))
+			}
+		})
+		b.Run(fmt.Sprintf("Where/n=%d", n), func(b *testing.B) {
+			l := plFill(n)
+			for i := range b.N {
+				l.Where(rand.Float64(), i%2 == 0)
+			}
+		})
+		b.Run(fmt.Sprintf("At/n=%d", n), func(b *testing.B) {
+			l := plFill(n)
+			for range b.N {
+				l.At(rand.IntN(n))
+			}
+		})
+	}
+}
+
+func plFill(n int) *PriorityList {
+	l := NewPriorityList()
+	for i := range n {
+		l.Insert(NewPriorityItem(rand.Float64(), i))
+	}
+	return l
+}
@@ -2,12 +2,20 @@ package datastruct
 
 import (
 	"container/heap"
+	"encoding/binary"
+	"encoding/json"
 	"errors"
+	"iter"
+	"math"
 )
 
 var (
 	// ErrEmpty is returned when an attempt is made to pop an empty PriorityQueue
 	ErrEmpty = errors.New("Empty queue")
+	// ErrDuplicateId is returned when an attempt is made to meld queues that share an id
+	ErrDuplicateId = errors.New("Duplicate id")
+	// ErrBadEncoding is returned when data being unmarshaled is malformed
+	ErrBadEncoding = errors.New("Bad encoding")
 )
 
 // PriorityQueue wraps a minQueue (see example in container/heap) to a straight integer id
@@ -56,6 +64,109 @@ func (pq *PriorityQueue) Pop() (int, error) {
 	return itm.id, nil
 }
 
+// All returns an iterator over the ids and priorities in the queue in priority order. The queue
+// isn't modified. Setting up the iteration is O(n), and each step O(log n).
+func (pq *PriorityQueue) All() iter.Seq2[int, float64] {
+	return func(yield func(int, float64) bool) {
+		// Iterate over a copy of the heap
+		itms := make([]pqitem, len(pq.items))
+		mq := make(minQueue, len(pq.items))
+		for i, itm := range pq.items {
+			itms[i] = *itm
+			mq[i] = &itms[i]
+		}
+		for len(mq) > 0 {
+			itm := heap.Pop(&mq).(*pqitem)
+			if !yield(itm.id, itm.priority) {
+				return
+			}
+		}
+	}
+}
+
+// pqVersion is the version of the binary encoding.
+const pqVersion = 1
+
+// MarshalBinary implements encoding.BinaryMarshaler. Entries are written in priority order, each
+// as a varint id followed by the priority as a little endian IEEE 754 float64.
+func (pq *PriorityQueue) MarshalBinary() ([]byte, error) {
+	res := []byte{pqVersion}
+	res = binary.AppendUvarint(res, uint64(pq.Len()))
+	for id, pri := range pq.All() {
+		res = binary.AppendVarint(res, int64(id))
+		res = binary.LittleEndian.AppendUint64(res, math.Float64bits(pri))
+	}
+	return res, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It replaces the contents of the queue.
+func (pq *PriorityQueue) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != pqVersion {
+		return ErrBadEncoding
+	}
+	data = data[1:]
+	n, l := binary.Uvarint(data)
+	if l <= 0 || n > uint64(len(data)) {
+		return ErrBadEncoding
+	}
+	data = data[l:]
+	items := make(minQueue, 0, n)
+	for i := uint64(0); i < n; i++ {
+		id, l := binary.Varint(data)
+		if l <= 0 || len(data) < l+8 {
+			return ErrBadEncoding
+		}
+		pri := math.Float64frombits(binary.LittleEndian.Uint64(data[l:]))
+		data = data[l+8:]
+		items = append(items, &pqitem{int(id), pri, -1})
+	}
+	if len(data) != 0 {
+		return ErrBadEncoding
+	}
+	pq.load(items)
+	return nil
+}
+
+// pqentry is the JSON form of a queue entry.
+type pqentry struct {
+	Id       int     `json:"id"`
+	Priority float64 `json:"priority"`
+}
+
+// MarshalJSON implements json.Marshaler. The queue is encoded as an array of id, priority objects
+// in priority order. Note that JSON can't represent infinite or NaN priorities.
+func (pq *PriorityQueue) MarshalJSON() ([]byte, error) {
+	entries := make([]pqentry, 0, pq.Len())
+	for id, pri := range pq.All() {
+		entries = append(entries, pqentry{id, pri})
+	}
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It replaces the contents of the queue.
+func (pq *PriorityQueue) UnmarshalJSON(data []byte) error {
+	var entries []pqentry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	items := make(minQueue, len(entries))
+	for i, e := range entries {
+		items[i] = &pqitem{e.Id, e.Priority, -1}
+	}
+	pq.load(items)
+	return nil
+}
+
+// load replaces the contents of the queue with items. If an id occurs more than once, the last
+// occurrence wins.
+func (pq *PriorityQueue) load(items minQueue) {
+	pq.id2itm = make(map[int]*pqitem, len(items))
+	pq.items = minQueue{}
+	for _, itm := range items {
+		pq.Insert(itm.id, itm.priority)
+	}
+}
+
 // Use container.heap to implement MinQueue (see example).
 // MinQueue must support heap.Interface and sort.Interface
 
@@ -0,0 +1,113 @@
+package datastruct
+
+import (
+	"errors"
+	"math/bits"
+)
+
+var (
+	// ErrMonotone is returned when an attempt is made to insert a priority into a RadixHeap that
+	// is lower than the last one popped
+	ErrMonotone = errors.New("Priority less than last popped")
+)
+
+// RadixHeap is a monotone integer id based priority queue with integer priorities. Priorities
+// inserted must be no lower than the last priority popped, which is the case for Dijkstra's
+// algorithm with non-negative edge weights. Insert is O(1) and Pop is O(log C) amortized,
+// where C is the largest priority.
+type RadixHeap struct {
+	last    uint64
+	buckets [65][]rhitem // bucket b holds priorities whose highest bit differing from last is b-1
+	id2loc  map[int]rhloc
+	n       int
+}
+
+type rhitem struct {
+	id       int
+	priority uint64
+}
+
+type rhloc struct {
+	bucket, slot int
+}
+
+// NewRadixHeap creates a new heap instance
+func NewRadixHeap() *RadixHeap {
+	return &RadixHeap{id2loc: make(map[int]rhloc)}
+}
+
+// Len returns the number of entries in the heap
+func (h *RadixHeap) Len() int {
+	return h.n
+}
+
+// Last returns the last priority popped from the heap
+func (h *RadixHeap) Last() uint64 {
+	return h.last
+}
+
+// Insert a new id with priority or change the priority of an existing id. ErrMonotone is
+// returned if the priority is less than the last one popped.
+func (h *RadixHeap) Insert(id int, pri uint64) error {
+	if pri < h.last {
+		return ErrMonotone
+	}
+	if loc, ok := h.id2loc[id]; ok {
+		h.remove(loc)
+	} else {
+		h.n++
+	}
+	h.push(rhitem{id, pri})
+	return nil
+}
+
+// Pop returns the lowest priority id and removes it from the heap
+func (h *RadixHeap) Pop() (int, error) {
+	if h.n == 0 {
+		return 0, ErrEmpty
+	}
+	if len(h.buckets[0]) == 0 {
+		// Find the first non-empty bucket and its minimum
+		b := 1
+		for len(h.buckets[b]) == 0 {
+			b++
+		}
+		items := h.buckets[b]
+		min := items[0].priority
+		for _, itm := range items[1:] {
+			if itm.priority < min {
+				min = itm.priority
+			}
+		}
+		// Redistribute the bucket's items, they all land in lower buckets
+		h.last = min
+		h.buckets[b] = items[:0]
+		for _, itm := range items {
+			h.push(itm)
+		}
+	}
+	b0 := h.buckets[0]
+	itm := b0[len(b0)-1]
+	h.buckets[0] = b0[:len(b0)-1]
+	delete(h.id2loc, itm.id)
+	h.n--
+	return itm.id, nil
+}
+
+// push adds the item to its bucket.
+func (h *RadixHeap) push(itm rhitem) {
+	b := bits.Len64(itm.priority ^ h.last)
+	h.id2loc[itm.id] = rhloc{b, len(h.buckets[b])}
+	h.buckets[b] = append(h.buckets[b], itm)
+}
+
+// remove deletes the item at loc by moving the last item in the bucket into its slot.
+func (h *RadixHeap) remove(loc rhloc) {
+	items := h.buckets[loc.bucket]
+	n := len(items) - 1
+	if loc.slot != n {
+		items[loc.slot] = items[n]
+		h.id2loc[items[n].id] = loc
+	}
+	h.buckets[loc.bucket] = items[:n]
+}
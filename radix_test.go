@@ -0,0 +1,94 @@
+package datastruct
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+// Compares RadixHeap with PriorityQueue when running Dijkstra over a grid graph with integer
+// weights on the cells.
+func BenchmarkRadixHeapDijkstra(b *testing.B) {
+	for _, n := range []int{100, 300, 1000} {
+		w := rhGrid(n)
+		want := rhDijkstraPQ(w, n)[n*n-1]
+		b.Run(fmt.Sprintf("PriorityQueue/%dx%d", n, n), func(b *testing.B) {
+			for range b.N {
+				rhDijkstraPQ(w, n)
+			}
+		})
+		b.Run(fmt.Sprintf("RadixHeap/%dx%d", n, n), func(b *testing.B) {
+			for range b.N {
+				if d := rhDijkstraRadix(w, n)[n*n-1]; d != want {
+					b.Fatalf("distance %d, want %d", d, want)
+				}
+			}
+		})
+	}
+}
+
+// rhGrid returns the cost of entering each cell in an n by n grid.
+func rhGrid(n int) []uint64 {
+	w := make([]uint64, n*n)
+	for i := range w {
+		w[i] = uint64(1 + rand.IntN(100))
+	}
+	return w
+}
+
+// rhNeighbors calls f for each of the 4-connected neighbors of cell c.
+func rhNeighbors(c, n int, f func(int)) {
+	r, col := c/n, c%n
+	if r > 0 {
+		f(c - n)
+	}
+	if r < n-1 {
+		f(c + n)
+	}
+	if col > 0 {
+		f(c - 1)
+	}
+	if col < n-1 {
+		f(c + 1)
+	}
+}
+
+func rhDijkstraPQ(w []uint64, n int) []uint64 {
+	dist := make([]uint64, n*n)
+	for i := range dist {
+		dist[i] = ^uint64(0)
+	}
+	dist[0] = 0
+	pq := NewPriorityQueue()
+	pq.Insert(0, 0)
+	for pq.Len() > 0 {
+		c, _ := pq.Pop()
+		rhNeighbors(c, n, func(nc int) {
+			if d := dist[c] + w[nc]; d < dist[nc] {
+				dist[nc] = d
+				pq.Insert(nc, float64(d))
+			}
+		})
+	}
+	return dist
+}
+
+func rhDijkstraRadix(w []uint64, n int) []uint64 {
+	dist := make([]uint64, n*n)
+	for i := range dist {
+		dist[i] = ^uint64(0)
+	}
+	dist[0] = 0
+	rh := NewRadixHeap()
+	rh.Insert(0, 0)
+	for rh.Len() > 0 {
+		c, _ := rh.Pop()
+		rhNeighbors(c, n, func(nc int) {
+			if d := dist[c] + w[nc]; d < dist[nc] {
+				dist[nc] = d
+				rh.Insert(nc, d)
+			}
+		})
+	}
+	return dist
+}
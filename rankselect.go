@@ -0,0 +1,75 @@
+package datastruct
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// RankSelect is an index over Bits which answers rank (how many bits are set before i) in O(1)
+// and select (where is the kth set bit) in O(log n). It stores a running count every rsWords
+// words, an overhead of 1/8 bit per bit. The index refers to the Bits it was built from, which
+// mustn't be changed while the index is in use.
+type RankSelect struct {
+	bits  Bits
+	super []int // super[i] is the number of bits set in words [0, i*rsWords)
+}
+
+const rsWords = 8
+
+// NewRankSelect builds the index for b.
+func NewRankSelect(b Bits) *RankSelect {
+	super := make([]int, (len(b)+rsWords-1)/rsWords+1)
+	n := 0
+	for i, w := range b {
+		if i%rsWords == 0 {
+			super[i/rsWords] = n
+		}
+		n += bits.OnesCount64(w)
+	}
+	super[len(super)-1] = n
+	return &RankSelect{b, super}
+}
+
+// Count returns the number of bits set.
+func (rs *RankSelect) Count() int {
+	return rs.super[len(rs.super)-1]
+}
+
+// Rank returns the number of bits set before bit i.
+func (rs *RankSelect) Rank(i int) int {
+	if i <= 0 {
+		return 0
+	}
+	if i >= len(rs.bits)*64 {
+		return rs.Count()
+	}
+	p := i / 64
+	n := rs.super[p/rsWords]
+	for j := p - p%rsWords; j < p; j++ {
+		n += bits.OnesCount64(rs.bits[j])
+	}
+	return n + bits.OnesCount64(rs.bits[p]&(uint64(1)<<uint(i%64)-1))
+}
+
+// Select returns the index of the kth set bit, counting from 0, or -1 if fewer than k+1 bits are
+// set. Rank(Select(k)) == k.
+func (rs *RankSelect) Select(k int) int {
+	if k < 0 || k >= rs.Count() {
+		return -1
+	}
+	// Last block starting with k or fewer bits set before it
+	s := sort.Search(len(rs.super), func(i int) bool { return rs.super[i] > k }) - 1
+	k -= rs.super[s]
+	for p := s * rsWords; ; p++ {
+		w := rs.bits[p]
+		c := bits.OnesCount64(w)
+		if k < c {
+			// Drop the k lowest set bits
+			for ; k > 0; k-- {
+				w &= w - 1
+			}
+			return p*64 + bits.TrailingZeros64(w)
+		}
+		k -= c
+	}
+}
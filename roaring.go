@@ -0,0 +1,920 @@
+package datastruct
+
+import (
+	"encoding/binary"
+	"fmt"
+	"iter"
+	"math/bits"
+	"slices"
+	"sort"
+)
+
+// Bitmap is a compressed set of uint32 elements in the style of Roaring bitmaps. Elements are
+// grouped into chunks by their high 16 bits, and each chunk is held in whichever container suits it:
+// a sorted array of the low 16 bits for sparse chunks, a 65536 bit bitmap for dense ones, or a list
+// of runs for chunks made up of long runs of consecutive elements (see RunOptimize).
+type Bitmap struct {
+	keys []uint16 // sorted high 16 bits of the chunks
+	cons []*rcontainer
+	tree []int // Fenwick tree of the container cardinalities, for Len, Rank and Select
+}
+
+// Container kinds
+const (
+	rArray = iota
+	rBitmap
+	rRun
+)
+
+const (
+	rArrayMax    = 4096 // largest array container, beyond which a bitmap is smaller
+	rBitmapWords = 1024 // 65536 bits
+)
+
+// rcontainer holds the low 16 bits of the elements in a chunk. Only the field for its kind is used.
+type rcontainer struct {
+	kind int
+	card int
+	arr  []uint16 // sorted
+	bmp  []uint64
+	runs []rrun // sorted, non-overlapping and non-adjacent
+}
+
+// rrun is an inclusive run of elements.
+type rrun struct {
+	start, last uint16
+}
+
+// NewBitmap returns a new bitmap with the provided elements in it.
+func NewBitmap(elts ...uint32) *Bitmap {
+	res := &Bitmap{}
+	for _, e := range elts {
+		res.Add(e)
+	}
+	return res
+}
+
+// Add adds the element e to the set and returns true if it wasn't already in the set.
+func (b *Bitmap) Add(e uint32) bool {
+	hi, lo := uint16(e>>16), uint16(e)
+	i, ok := slices.BinarySearch(b.keys, hi)
+	if !ok {
+		b.keys = slices.Insert(b.keys, i, hi)
+		b.cons = slices.Insert(b.cons, i, &rcontainer{kind: rArray})
+		b.cons[i].add(lo)
+		b.reindex()
+		return true
+	}
+	if !b.cons[i].add(lo) {
+		return false
+	}
+	b.update(i, 1)
+	return true
+}
+
+// Remove removes element e from the set and returns true if it was in the set.
+func (b *Bitmap) Remove(e uint32) bool {
+	hi, lo := uint16(e>>16), uint16(e)
+	i, ok := slices.BinarySearch(b.keys, hi)
+	if !ok || !b.cons[i].remove(lo) {
+		return false
+	}
+	if b.cons[i].card == 0 {
+		b.keys = slices.Delete(b.keys, i, i+1)
+		b.cons = slices.Delete(b.cons, i, i+1)
+		b.reindex()
+	} else {
+		b.update(i, -1)
+	}
+	return true
+}
+
+// Element returns true if the set contains the element e.
+func (b *Bitmap) Element(e uint32) bool {
+	i, ok := slices.BinarySearch(b.keys, uint16(e>>16))
+	return ok && b.cons[i].contains(uint16(e))
+}
+
+// Empty returns true if the set is the empty set.
+func (b *Bitmap) Empty() bool {
+	return len(b.keys) == 0
+}
+
+// Len returns the number of elements in the set (its cardinality).
+func (b *Bitmap) Len() int {
+	return b.prefix(len(b.cons))
+}
+
+// Copy makes a copy of the set.
+func (b *Bitmap) Copy() *Bitmap {
+	res := &Bitmap{slices.Clone(b.keys), make([]*rcontainer, len(b.cons)), slices.Clone(b.tree)}
+	for i, c := range b.cons {
+		res.cons[i] = c.clone()
+	}
+	return res
+}
+
+// Rank returns the number of elements in the set that are less than or equal to e. Rank and
+// Select use a Fenwick tree of the container cardinalities to find the container in O(log n).
+func (b *Bitmap) Rank(e uint32) int {
+	hi := uint16(e >> 16)
+	i, ok := slices.BinarySearch(b.keys, hi)
+	n := b.prefix(i)
+	if ok {
+		n += b.cons[i].rank(uint16(e))
+	}
+	return n
+}
+
+// Select returns the kth smallest element in the set, starting from 0. If k is out of range then
+// false is returned.
+func (b *Bitmap) Select(k int) (uint32, bool) {
+	if k < 0 || k >= b.Len() {
+		return 0, false
+	}
+	// Find the last container with k or fewer elements before it
+	i := 0
+	for step := 1 << (bits.Len(uint(len(b.cons))) - 1); step > 0; step >>= 1 {
+		if j := i + step; j <= len(b.cons) && b.tree[j] <= k {
+			i = j
+			k -= b.tree[j]
+		}
+	}
+	return uint32(b.keys[i])<<16 | uint32(b.cons[i].selectk(k)), true
+}
+
+// reindex rebuilds the Fenwick tree after containers are added or removed. This is O(n) in the
+// number of containers, as is inserting or deleting a container.
+func (b *Bitmap) reindex() {
+	n := len(b.cons)
+	b.tree = make([]int, n+1)
+	for i, c := range b.cons {
+		j := i + 1
+		b.tree[j] += c.card
+		if p := j + j&-j; p <= n {
+			b.tree[p] += b.tree[j]
+		}
+	}
+}
+
+// update adds delta to the cardinality of container i in the Fenwick tree.
+func (b *Bitmap) update(i, delta int) {
+	for j := i + 1; j < len(b.tree); j += j & -j {
+		b.tree[j] += delta
+	}
+}
+
+// prefix returns the number of elements in the first i containers.
+func (b *Bitmap) prefix(i int) int {
+	n := 0
+	for ; i > 0; i -= i & -i {
+		n += b.tree[i]
+	}
+	return n
+}
+
+// And returns a new set containing the intersection of the set and o.
+func (b *Bitmap) And(o *Bitmap) *Bitmap {
+	res := &Bitmap{}
+	i, j := 0, 0
+	for i < len(b.keys) && j < len(o.keys) {
+		switch {
+		case b.keys[i] < o.keys[j]:
+			i++
+		case b.keys[i] > o.keys[j]:
+			j++
+		default:
+			if c := rcAnd(b.cons[i], o.cons[j]); c.card > 0 {
+				res.keys = append(res.keys, b.keys[i])
+				res.cons = append(res.cons, c)
+			}
+			i++
+			j++
+		}
+	}
+	res.reindex()
+	return res
+}
+
+// Or returns a new set containing the union of the set and o.
+func (b *Bitmap) Or(o *Bitmap) *Bitmap {
+	return b.merge(o, rcOr, true)
+}
+
+// Xor returns a new set containing the elements in either the set or o but not in both.
+func (b *Bitmap) Xor(o *Bitmap) *Bitmap {
+	return b.merge(o, rcXor, true)
+}
+
+// AndNot returns a new set containing the elements in the set which are not in o.
+func (b *Bitmap) AndNot(o *Bitmap) *Bitmap {
+	return b.merge(o, rcAndNot, false)
+}
+
+// merge combines the containers of b and o with op where keys match. Containers only in b are
+// copied, as are those only in o if all is set.
+func (b *Bitmap) merge(o *Bitmap, op func(a, b *rcontainer) *rcontainer, all bool) *Bitmap {
+	res := &Bitmap{}
+	add := func(k uint16, c *rcontainer) {
+		if c.card > 0 {
+			res.keys = append(res.keys, k)
+			res.cons = append(res.cons, c)
+		}
+	}
+	i, j := 0, 0
+	for i < len(b.keys) || j < len(o.keys) {
+		switch {
+		case j == len(o.keys) || (i < len(b.keys) && b.keys[i] < o.keys[j]):
+			add(b.keys[i], b.cons[i].clone())
+			i++
+		case i == len(b.keys) || b.keys[i] > o.keys[j]:
+			if all {
+				add(o.keys[j], o.cons[j].clone())
+			}
+			j++
+		default:
+			add(b.keys[i], op(b.cons[i], o.cons[j]))
+			i++
+			j++
+		}
+	}
+	res.reindex()
+	return res
+}
+
+// RunOptimize converts each container to whichever of the array, bitmap or run forms is the
+// smallest. Run containers suit chunks made of long runs of consecutive elements.
+func (b *Bitmap) RunOptimize() {
+	for i, c := range b.cons {
+		b.cons[i] = c.optimize()
+	}
+}
+
+// All returns an iterator over the elements of the set in ascending order.
+func (b *Bitmap) All() iter.Seq[uint32] {
+	return func(yield func(uint32) bool) {
+		for i, c := range b.cons {
+			hi := uint32(b.keys[i]) << 16
+			for lo := range c.all() {
+				if !yield(hi | uint32(lo)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Slice returns a slice representation of the set in ascending order.
+func (b *Bitmap) Slice() []uint32 {
+	res := make([]uint32, 0, b.Len())
+	for e := range b.All() {
+		res = append(res, e)
+	}
+	return res
+}
+
+// String returns a string representation of the set in ascending order.
+func (b *Bitmap) String() string {
+	res := "{"
+	first := true
+	for e := range b.All() {
+		if first {
+			res += fmt.Sprintf("%d", e)
+			first = false
+		} else {
+			res += fmt.Sprintf(", %d", e)
+		}
+	}
+	return res + "}"
+}
+
+// The binary encoding is the standard Roaring format
+// (https://github.com/RoaringBitmap/RoaringFormatSpec), so bitmaps can be exchanged with other
+// Roaring implementations. All values are little endian:
+//
+//	without run containers: cookie 12346 (uint32), number of containers (uint32)
+//	with run containers:    cookie 12347 | (number of containers - 1) << 16 (uint32),
+//	                        run flags, one bit per container ((n+7)/8 bytes)
+//	for each container, key (uint16) and cardinality - 1 (uint16)
+//	for each container, byte offset from the start (uint32), omitted if there are run
+//	containers and fewer than 4 containers
+//	containers in key order:
+//	  array (cardinality <= 4096): cardinality uint16 values in ascending order
+//	  bitmap (cardinality > 4096): 1024 uint64 words
+//	  run: number of runs (uint16), then pairs of uint16 start and length - 1
+const (
+	rCookieNoRuns    = 12346
+	rCookieRuns      = 12347
+	rNoOffsetMaximum = 4
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (b *Bitmap) MarshalBinary() ([]byte, error) {
+	n := len(b.keys)
+	hasRuns := slices.ContainsFunc(b.cons, func(c *rcontainer) bool { return c.kind == rRun })
+	var res []byte
+	if hasRuns {
+		res = binary.LittleEndian.AppendUint32(res, rCookieRuns|uint32(n-1)<<16)
+		flags := make([]byte, (n+7)/8)
+		for i, c := range b.cons {
+			if c.kind == rRun {
+				flags[i/8] |= 1 << (i % 8)
+			}
+		}
+		res = append(res, flags...)
+	} else {
+		res = binary.LittleEndian.AppendUint32(res, rCookieNoRuns)
+		res = binary.LittleEndian.AppendUint32(res, uint32(n))
+	}
+	for i, c := range b.cons {
+		res = binary.LittleEndian.AppendUint16(res, b.keys[i])
+		res = binary.LittleEndian.AppendUint16(res, uint16(c.card-1))
+	}
+	if !hasRuns || n >= rNoOffsetMaximum {
+		off := len(res) + 4*n
+		for _, c := range b.cons {
+			res = binary.LittleEndian.AppendUint32(res, uint32(off))
+			off += c.size()
+		}
+	}
+	for _, c := range b.cons {
+		switch {
+		case c.kind == rRun:
+			res = binary.LittleEndian.AppendUint16(res, uint16(len(c.runs)))
+			for _, r := range c.runs {
+				res = binary.LittleEndian.AppendUint16(res, r.start)
+				res = binary.LittleEndian.AppendUint16(res, r.last-r.start)
+			}
+		case c.card > rArrayMax:
+			for _, w := range c.words() {
+				res = binary.LittleEndian.AppendUint64(res, w)
+			}
+		default:
+			for v := range c.all() {
+				res = binary.LittleEndian.AppendUint16(res, v)
+			}
+		}
+	}
+	return res, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It replaces the contents of the set.
+func (b *Bitmap) UnmarshalBinary(data []byte) error {
+	if len(data) < 4 {
+		return ErrBadEncoding
+	}
+	cookie := binary.LittleEndian.Uint32(data)
+	pos, n := 4, 0
+	var flags []byte
+	switch {
+	case cookie == rCookieNoRuns:
+		if len(data) < 8 {
+			return ErrBadEncoding
+		}
+		n, pos = int(binary.LittleEndian.Uint32(data[4:])), 8
+	case cookie&0xffff == rCookieRuns:
+		n = int(cookie>>16) + 1
+		if len(data) < pos+(n+7)/8 {
+			return ErrBadEncoding
+		}
+		flags = data[pos : pos+(n+7)/8]
+		pos += len(flags)
+	default:
+		return ErrBadEncoding
+	}
+	if n > 1<<16 || (len(data)-pos)/4 < n {
+		return ErrBadEncoding
+	}
+	hdr := data[pos:]
+	pos += 4 * n
+	var offs []byte
+	if flags == nil || n >= rNoOffsetMaximum {
+		if (len(data)-pos)/4 < n {
+			return ErrBadEncoding
+		}
+		offs = data[pos:]
+		pos += 4 * n
+	}
+
+	keys := make([]uint16, n)
+	cons := make([]*rcontainer, n)
+	for i := range n {
+		keys[i] = binary.LittleEndian.Uint16(hdr[4*i:])
+		card := int(binary.LittleEndian.Uint16(hdr[4*i+2:])) + 1
+		if i > 0 && keys[i] <= keys[i-1] {
+			return ErrBadEncoding
+		}
+		// Containers are contiguous, so the offsets are checked rather than used
+		if offs != nil && int(binary.LittleEndian.Uint32(offs[4*i:])) != pos {
+			return ErrBadEncoding
+		}
+		var c *rcontainer
+		var l int
+		switch {
+		case flags != nil && flags[i/8]&(1<<(i%8)) != 0:
+			c, l = rdecodeRuns(data[pos:])
+		case card > rArrayMax:
+			c, l = rdecodeBitmap(data[pos:])
+		default:
+			c, l = rdecodeArray(data[pos:], card)
+		}
+		if c == nil || c.card != card {
+			return ErrBadEncoding
+		}
+		cons[i] = c
+		pos += l
+	}
+	if pos != len(data) {
+		return ErrBadEncoding
+	}
+	b.keys, b.cons = keys, cons
+	b.reindex()
+	return nil
+}
+
+// rdecodeArray decodes an array container of card values and returns it and its size in bytes,
+// or nil if it's invalid.
+func rdecodeArray(data []byte, card int) (*rcontainer, int) {
+	if len(data) < 2*card {
+		return nil, 0
+	}
+	arr := make([]uint16, card)
+	for i := range arr {
+		arr[i] = binary.LittleEndian.Uint16(data[2*i:])
+		if i > 0 && arr[i] <= arr[i-1] {
+			return nil, 0
+		}
+	}
+	return &rcontainer{kind: rArray, card: card, arr: arr}, 2 * card
+}
+
+// rdecodeBitmap decodes a bitmap container and returns it and its size in bytes, or nil if it's
+// invalid.
+func rdecodeBitmap(data []byte) (*rcontainer, int) {
+	if len(data) < 8*rBitmapWords {
+		return nil, 0
+	}
+	bmp := make([]uint64, rBitmapWords)
+	card := 0
+	for i := range bmp {
+		bmp[i] = binary.LittleEndian.Uint64(data[8*i:])
+		card += bits.OnesCount64(bmp[i])
+	}
+	return &rcontainer{kind: rBitmap, card: card, bmp: bmp}, 8 * rBitmapWords
+}
+
+// rdecodeRuns decodes a run container and returns it and its size in bytes, or nil if it's
+// invalid. Adjacent runs, which other implementations may write, are joined.
+func rdecodeRuns(data []byte) (*rcontainer, int) {
+	if len(data) < 2 {
+		return nil, 0
+	}
+	nr := int(binary.LittleEndian.Uint16(data))
+	if nr == 0 || len(data) < 2+4*nr {
+		return nil, 0
+	}
+	runs := make([]rrun, 0, nr)
+	card := 0
+	for i := range nr {
+		start, l := int(binary.LittleEndian.Uint16(data[2+4*i:])), int(binary.LittleEndian.Uint16(data[4+4*i:]))
+		last := start + l
+		if last > 0xffff {
+			return nil, 0
+		}
+		if k := len(runs); k > 0 {
+			prev := int(runs[k-1].last)
+			if start <= prev {
+				return nil, 0
+			}
+			if start == prev+1 {
+				runs[k-1].last = uint16(last)
+				card += l + 1
+				continue
+			}
+		}
+		runs = append(runs, rrun{uint16(start), uint16(last)})
+		card += l + 1
+	}
+	return &rcontainer{kind: rRun, card: card, runs: runs}, 2 + 4*nr
+}
+
+// size returns the number of bytes the container takes in the binary encoding.
+func (c *rcontainer) size() int {
+	switch {
+	case c.kind == rRun:
+		return 2 + 4*len(c.runs)
+	case c.card > rArrayMax:
+		return 8 * rBitmapWords
+	}
+	return 2 * c.card
+}
+
+// Container operations
+
+func (c *rcontainer) contains(x uint16) bool {
+	switch c.kind {
+	case rArray:
+		_, ok := slices.BinarySearch(c.arr, x)
+		return ok
+	case rBitmap:
+		return c.bmp[x/64]&(uint64(1)<<(x%64)) != 0
+	}
+	i := c.findRun(x)
+	return i < len(c.runs) && c.runs[i].start <= x
+}
+
+// add adds x to the container and returns true if it wasn't already present. Run containers stay
+// as runs, even if another form becomes smaller; RunOptimize picks the smallest form again.
+func (c *rcontainer) add(x uint16) bool {
+	if c.kind == rRun {
+		return c.addRun(x)
+	}
+	if c.kind == rBitmap {
+		w, m := x/64, uint64(1)<<(x%64)
+		if c.bmp[w]&m != 0 {
+			return false
+		}
+		c.bmp[w] |= m
+		c.card++
+		return true
+	}
+	i, ok := slices.BinarySearch(c.arr, x)
+	if ok {
+		return false
+	}
+	c.arr = slices.Insert(c.arr, i, x)
+	c.card++
+	if c.card > rArrayMax {
+		c.bmp = c.words()
+		c.arr = nil
+		c.kind = rBitmap
+	}
+	return true
+}
+
+// remove removes x from the container and returns true if it was present. As with add, run
+// containers stay as runs.
+func (c *rcontainer) remove(x uint16) bool {
+	if c.kind == rRun {
+		return c.removeRun(x)
+	}
+	if c.kind == rBitmap {
+		w, m := x/64, uint64(1)<<(x%64)
+		if c.bmp[w]&m == 0 {
+			return false
+		}
+		c.bmp[w] &^= m
+		c.card--
+		if c.card <= rArrayMax {
+			*c = *rfromWords(c.bmp)
+		}
+		return true
+	}
+	i, ok := slices.BinarySearch(c.arr, x)
+	if !ok {
+		return false
+	}
+	c.arr = slices.Delete(c.arr, i, i+1)
+	c.card--
+	return true
+}
+
+// addRun adds x to a run container, extending or joining runs where x is adjacent to them.
+func (c *rcontainer) addRun(x uint16) bool {
+	i := c.findRun(x)
+	if i < len(c.runs) && c.runs[i].start <= x {
+		return false
+	}
+	joinPrev := i > 0 && int(c.runs[i-1].last)+1 == int(x)
+	joinNext := i < len(c.runs) && int(c.runs[i].start) == int(x)+1
+	switch {
+	case joinPrev && joinNext:
+		c.runs[i-1].last = c.runs[i].last
+		c.runs = slices.Delete(c.runs, i, i+1)
+	case joinPrev:
+		c.runs[i-1].last = x
+	case joinNext:
+		c.runs[i].start = x
+	default:
+		c.runs = slices.Insert(c.runs, i, rrun{x, x})
+	}
+	c.card++
+	return true
+}
+
+// removeRun removes x from a run container, shrinking or splitting the run holding it.
+func (c *rcontainer) removeRun(x uint16) bool {
+	i := c.findRun(x)
+	if i == len(c.runs) || c.runs[i].start > x {
+		return false
+	}
+	r := c.runs[i]
+	switch {
+	case r.start == r.last:
+		c.runs = slices.Delete(c.runs, i, i+1)
+	case x == r.start:
+		c.runs[i].start++
+	case x == r.last:
+		c.runs[i].last--
+	default:
+		c.runs[i].last = x - 1
+		c.runs = slices.Insert(c.runs, i+1, rrun{x + 1, r.last})
+	}
+	c.card--
+	return true
+}
+
+// findRun returns the index of the first run ending at or after x.
+func (c *rcontainer) findRun(x uint16) int {
+	return sort.Search(len(c.runs), func(i int) bool { return c.runs[i].last >= x })
+}
+
+// rank returns the number of elements less than or equal to x.
+func (c *rcontainer) rank(x uint16) int {
+	switch c.kind {
+	case rArray:
+		i, ok := slices.BinarySearch(c.arr, x)
+		if ok {
+			i++
+		}
+		return i
+	case rBitmap:
+		n := 0
+		w := int(x / 64)
+		for _, v := range c.bmp[:w] {
+			n += bits.OnesCount64(v)
+		}
+		// Bits up to and including x
+		m := ^uint64(0) >> (63 - x%64)
+		return n + bits.OnesCount64(c.bmp[w]&m)
+	}
+	n := 0
+	for _, r := range c.runs {
+		if r.start > x {
+			break
+		}
+		if r.last >= x {
+			return n + int(x-r.start) + 1
+		}
+		n += int(r.last-r.start) + 1
+	}
+	return n
+}
+
+// selectk returns the kth smallest element, k must be in range.
+func (c *rcontainer) selectk(k int) uint16 {
+	switch c.kind {
+	case rArray:
+		return c.arr[k]
+	case rBitmap:
+		for i, w := range c.bmp {
+			n := bits.OnesCount64(w)
+			if k >= n {
+				k -= n
+				continue
+			}
+			for ; k > 0; k-- {
+				w &= w - 1
+			}
+			return uint16(i*64 + bits.TrailingZeros64(w))
+		}
+	}
+	for _, r := range c.runs {
+		n := int(r.last-r.start) + 1
+		if k < n {
+			return r.start + uint16(k)
+		}
+		k -= n
+	}
+	return 0
+}
+
+func (c *rcontainer) all() iter.Seq[uint16] {
+	return func(yield func(uint16) bool) {
+		switch c.kind {
+		case rArray:
+			for _, v := range c.arr {
+				if !yield(v) {
+					return
+				}
+			}
+		case rBitmap:
+			for i, w := range c.bmp {
+				for w != 0 {
+					if !yield(uint16(i*64 + bits.TrailingZeros64(w))) {
+						return
+					}
+					w &= w - 1
+				}
+			}
+		case rRun:
+			for _, r := range c.runs {
+				for v := int(r.start); v <= int(r.last); v++ {
+					if !yield(uint16(v)) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+func (c *rcontainer) clone() *rcontainer {
+	return &rcontainer{c.kind, c.card, slices.Clone(c.arr), slices.Clone(c.bmp), slices.Clone(c.runs)}
+}
+
+// words returns the container as a new bitmap.
+func (c *rcontainer) words() []uint64 {
+	if c.kind == rBitmap {
+		return slices.Clone(c.bmp)
+	}
+	res := make([]uint64, rBitmapWords)
+	if c.kind == rArray {
+		for _, v := range c.arr {
+			res[v/64] |= uint64(1) << (v % 64)
+		}
+		return res
+	}
+	for _, r := range c.runs {
+		rsetRange(res, int(r.start), int(r.last)+1)
+	}
+	return res
+}
+
+// rsetRange sets bits [s, e) in words.
+func rsetRange(words []uint64, s, e int) {
+	for s < e {
+		w, b := s/64, s%64
+		n := min(64-b, e-s)
+		m := ^uint64(0) >> (64 - n) << b
+		words[w] |= m
+		s += n
+	}
+}
+
+// rfromWords returns an array or bitmap container, depending on the number of bits set in words.
+func rfromWords(words []uint64) *rcontainer {
+	card := 0
+	for _, w := range words {
+		card += bits.OnesCount64(w)
+	}
+	if card > rArrayMax {
+		return &rcontainer{kind: rBitmap, card: card, bmp: words}
+	}
+	arr := make([]uint16, 0, card)
+	for i, w := range words {
+		for w != 0 {
+			arr = append(arr, uint16(i*64+bits.TrailingZeros64(w)))
+			w &= w - 1
+		}
+	}
+	return &rcontainer{kind: rArray, card: card, arr: arr}
+}
+
+// optimize returns the smallest representation of the container.
+func (c *rcontainer) optimize() *rcontainer {
+	runs := []rrun{}
+	for v := range c.all() {
+		if n := len(runs); n > 0 && int(runs[n-1].last)+1 == int(v) {
+			runs[n-1].last = v
+		} else {
+			runs = append(runs, rrun{v, v})
+		}
+	}
+	// Sizes in bytes
+	rs, as, bs := 2+4*len(runs), 2*c.card, 8*rBitmapWords
+	switch {
+	case rs < as && rs < bs:
+		return &rcontainer{kind: rRun, card: c.card, runs: runs}
+	case c.kind == rRun:
+		return rfromWords(c.words())
+	}
+	return c
+}
+
+// rcAnd returns the intersection of a and b.
+func rcAnd(a, b *rcontainer) *rcontainer {
+	if b.kind == rArray && a.kind != rArray {
+		a, b = b, a
+	}
+	if a.kind == rArray {
+		arr := []uint16{}
+		if b.kind == rArray {
+			// Merge the two sorted arrays
+			i, j := 0, 0
+			for i < len(a.arr) && j < len(b.arr) {
+				switch {
+				case a.arr[i] < b.arr[j]:
+					i++
+				case a.arr[i] > b.arr[j]:
+					j++
+				default:
+					arr = append(arr, a.arr[i])
+					i++
+					j++
+				}
+			}
+		} else {
+			for _, v := range a.arr {
+				if b.contains(v) {
+					arr = append(arr, v)
+				}
+			}
+		}
+		return &rcontainer{kind: rArray, card: len(arr), arr: arr}
+	}
+	words := a.words()
+	bw := b.bmp
+	if b.kind != rBitmap {
+		bw = b.words()
+	}
+	for i := range words {
+		words[i] &= bw[i]
+	}
+	return rfromWords(words)
+}
+
+// rcOr returns the union of a and b.
+func rcOr(a, b *rcontainer) *rcontainer {
+	if a.kind == rArray && b.kind == rArray && a.card+b.card <= rArrayMax {
+		arr := make([]uint16, 0, a.card+b.card)
+		i, j := 0, 0
+		for i < len(a.arr) || j < len(b.arr) {
+			switch {
+			case j == len(b.arr) || (i < len(a.arr) && a.arr[i] < b.arr[j]):
+				arr = append(arr, a.arr[i])
+				i++
+			case i == len(a.arr) || a.arr[i] > b.arr[j]:
+				arr = append(arr, b.arr[j])
+				j++
+			default:
+				arr = append(arr, a.arr[i])
+				i++
+				j++
+			}
+		}
+		return &rcontainer{kind: rArray, card: len(arr), arr: arr}
+	}
+	words := a.words()
+	if b.kind == rArray {
+		for _, v := range b.arr {
+			words[v/64] |= uint64(1) << (v % 64)
+		}
+	} else {
+		bw := b.bmp
+		if b.kind != rBitmap {
+			bw = b.words()
+		}
+		for i := range words {
+			words[i] |= bw[i]
+		}
+	}
+	return rfromWords(words)
+}
+
+// rcXor returns the elements in either a or b but not in both.
+func rcXor(a, b *rcontainer) *rcontainer {
+	words := a.words()
+	if b.kind == rArray {
+		for _, v := range b.arr {
+			words[v/64] ^= uint64(1) << (v % 64)
+		}
+	} else {
+		bw := b.bmp
+		if b.kind != rBitmap {
+			bw = b.words()
+		}
+		for i := range words {
+			words[i] ^= bw[i]
+		}
+	}
+	return rfromWords(words)
+}
+
+// rcAndNot returns the elements in a which are not in b.
+func rcAndNot(a, b *rcontainer) *rcontainer {
+	if a.kind == rArray {
+		arr := []uint16{}
+		for _, v := range a.arr {
+			if !b.contains(v) {
+				arr = append(arr, v)
+			}
+		}
+		return &rcontainer{kind: rArray, card: len(arr), arr: arr}
+	}
+	words := a.words()
+	if b.kind == rArray {
+		for _, v := range b.arr {
+			words[v/64] &^= uint64(1) << (v % 64)
+		}
+	} else {
+		bw := b.bmp
+		if b.kind != rBitmap {
+			bw = b.words()
+		}
+		for i := range words {
+			words[i] &^= bw[i]
+		}
+	}
+	return rfromWords(words)
+}
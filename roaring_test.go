@@ -0,0 +1,136 @@
+package datastruct
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestBitmapMarshalStandardFormat(t *testing.T) {
+	// No run containers: cookie, count, key and cardinality-1, offset, values
+	b := NewBitmap(1, 2, 3)
+	want := []byte{
+		0x3a, 0x30, 0, 0, 1, 0, 0, 0,
+		0, 0, 2, 0,
+		16, 0, 0, 0,
+		1, 0, 2, 0, 3, 0,
+	}
+	if got, _ := b.MarshalBinary(); !bytes.Equal(got, want) {
+		t.Fatalf("got % x, want % x", got, want)
+	}
+
+	// Run container: cookie with count-1, run flags, key and cardinality-1, no offsets, runs
+	b = NewBitmap()
+	for i := uint32(1); i <= 100; i++ {
+		b.Add(i)
+	}
+	b.RunOptimize()
+	want = []byte{
+		0x3b, 0x30, 0, 0, 1,
+		0, 0, 99, 0,
+		1, 0, 1, 0, 99, 0,
+	}
+	if got, _ := b.MarshalBinary(); !bytes.Equal(got, want) {
+		t.Fatalf("got % x, want % x", got, want)
+	}
+}
+
+func TestBitmapMarshalRoundTrip(t *testing.T) {
+	b := NewBitmap()
+	for i := uint32(0); i < 10000; i++ {
+		b.Add(i * 3)             // bitmap containers
+		b.Add(1<<20 + i)         // run container once optimized
+		b.Add(1<<24 + i*1000003) // array containers
+	}
+	for _, opt := range []bool{false, true} {
+		if opt {
+			b.RunOptimize()
+		}
+		data, _ := b.MarshalBinary()
+		var c Bitmap
+		if err := c.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+		if c.Len() != b.Len() || !c.Xor(b).Empty() {
+			t.Fatal("round trip changed the set")
+		}
+		for _, i := range []int{0, 3, 7, 11, len(data) / 3, len(data) / 2, len(data) - 1} {
+			if err := c.UnmarshalBinary(data[:i]); !errors.Is(err, ErrBadEncoding) {
+				t.Fatalf("truncated to %d: got %v, want ErrBadEncoding", i, err)
+			}
+		}
+	}
+}
+
+func TestBitmapRunMutationAndRank(t *testing.T) {
+	b := NewBitmap()
+	ref := map[uint32]bool{}
+	for i := uint32(0); i < 3000; i++ {
+		b.Add(i)
+		b.Add(5<<16 + i*2)
+		ref[i], ref[5<<16+i*2] = true, true
+	}
+	b.RunOptimize()
+	if b.cons[0].kind != rRun {
+		t.Fatal("first container isn't a run container")
+	}
+	// Split, shrink, extend and join runs
+	for _, e := range []uint32{1500, 0, 2999, 1500, 3000, 3001, 1, 1501, 1499, 65535, 65534} {
+		if ref[e] {
+			if !b.Remove(e) {
+				t.Fatalf("Remove(%d) = false", e)
+			}
+			delete(ref, e)
+		} else {
+			if !b.Add(e) {
+				t.Fatalf("Add(%d) = false", e)
+			}
+			ref[e] = true
+		}
+		if b.cons[0].kind != rRun {
+			t.Fatalf("run container converted after %d", e)
+		}
+		if b.Len() != len(ref) {
+			t.Fatalf("Len() = %d, want %d", b.Len(), len(ref))
+		}
+	}
+	k := 0
+	for e := range uint32(6 << 16) {
+		if ref[e] {
+			if s, ok := b.Select(k); !ok || s != e {
+				t.Fatalf("Select(%d) = %d, %v, want %d", k, s, ok, e)
+			}
+			k++
+		}
+		if !b.Element(e) == ref[e] {
+			t.Fatalf("Element(%d) = %v", e, !ref[e])
+		}
+		if r := b.Rank(e); r != k {
+			t.Fatalf("Rank(%d) = %d, want %d", e, r, k)
+		}
+	}
+	if _, ok := b.Select(k); ok {
+		t.Fatal("Select past the end succeeded")
+	}
+}
+
+func TestBitmapConcurrentReads(t *testing.T) {
+	b := NewBitmap()
+	for i := uint32(0); i < 1<<20; i += 7 {
+		b.Add(i)
+	}
+	done := make(chan bool)
+	for range 4 {
+		go func() {
+			for k := range 1000 {
+				if e, ok := b.Select(k * 100); !ok || b.Rank(e) != k*100+1 {
+					t.Errorf("Select(%d) = %d, Rank = %d", k*100, e, b.Rank(e))
+				}
+			}
+			done <- true
+		}()
+	}
+	for range 4 {
+		<-done
+	}
+}
@@ -1,8 +1,19 @@
 package datastruct
 
-import "fmt"
+import (
+	"cmp"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+)
 
-// Set represents a set of integer elements.
+// Set represents a set of integer elements. Removed elements are kept as false entries until
+// Purge is called, so Len and the set operations are O(n) in the number of entries ever added.
+// HashSet deletes removed elements and has an O(1) Len.
 type Set map[int]bool
 
 // NewSet returns a new set with the provided elements in it.
@@ -17,24 +28,13 @@ func NewSet(elts ...int) Set {
 // Add adds the element e to the set and returns true if it wasn't already in the set. Adding
 // an element when it already exists is a no-op signified by false.
 func (s Set) Add(e int) bool {
-	_, ok := s[e]
-	if !ok {
-		s[e] = true
-		return true
-	}
-	return false
+	return setAdd(s, e)
 }
 
 // Remove removes element e from the set, if it exists by setting it to false, and returns true if it was in the set.
 // Removing a non-existent element is a no-op signified by false. Use Purge() to actually shrink the set.
 func (s Set) Remove(e int) bool {
-	v, ok := s[e]
-	if !ok || !v {
-		return false
-	}
-	s[e] = false
-	//delete(s, e)
-	return true
+	return setRemove(s, e)
 }
 
 // Element returns true if the set contains the element e.
@@ -49,36 +49,17 @@ func (s Set) Empty() bool {
 
 // Len returns the number of elements in the set.
 func (s Set) Len() int {
-	if len(s) == 0 {
-		return 0
-	}
-	n := 0
-	for _, v := range s {
-		if v {
-			n++
-		}
-	}
-	return n
+	return setLen(s)
 }
 
 // Copy makes a copy of the set.
 func (s Set) Copy() Set {
-	res := make(Set)
-	for k, v := range s {
-		if v {
-			res[k] = v
-		}
-	}
-	return res
+	return setCopy(s)
 }
 
 // Purge clears out removed entries from the set.
 func (s Set) Purge() {
-	for k, v := range s {
-		if !v {
-			delete(s, k)
-		}
-	}
+	setPurge(s)
 }
 
 // Union returns a new set containing the union of the set and b (OR).
@@ -111,22 +92,37 @@ func (s Set) Disjoint(b Set) bool {
 	return Disjoint(s, b)
 }
 
-// String returns a string representation of the set.
+// UnionWith adds the elements of b to the set (OR).
+func (s Set) UnionWith(b Set) {
+	setUnionWith(s, b)
+}
+
+// IntersectWith removes the elements from the set that aren't in b (AND).
+func (s Set) IntersectWith(b Set) {
+	setIntersectWith(s, b)
+}
+
+// DifferenceWith leaves only the elements in either the set or b but not in both (XOR).
+func (s Set) DifferenceWith(b Set) {
+	setDifferenceWith(s, b)
+}
+
+// SubWith removes the elements in b from the set (SUB).
+func (s Set) SubWith(b Set) {
+	setSubWith(s, b)
+}
+
+// String returns a string representation of the set in ascending order.
 func (s Set) String() string {
 	if s.Empty() {
 		return "{}"
 	}
 	res := "{"
-	first := true
-	for k, v := range s {
-		if !v {
-			continue
-		}
-		if first {
-			res += fmt.Sprintf("%d", k)
-			first = false
+	for i, e := range s.Sorted() {
+		if i == 0 {
+			res += fmt.Sprintf("%d", e)
 		} else {
-			res += fmt.Sprintf(", %d", k)
+			res += fmt.Sprintf(", %d", e)
 		}
 	}
 	return res + "}"
@@ -134,22 +130,151 @@ func (s Set) String() string {
 
 // Slice returns an unsorted slice representation of the set.
 func (s Set) Slice() []int {
-	n := s.Len()
-	res := make([]int, n)
-	i := 0
-	for k, v := range s {
-		if !v {
-			continue
+	return setSlice(s)
+}
+
+// Sorted returns a slice representation of the set in ascending order.
+func (s Set) Sorted() []int {
+	return SetSorted(s)
+}
+
+// All returns an iterator over the elements of the set in ascending order.
+func (s Set) All() iter.Seq[int] {
+	return slices.Values(s.Sorted())
+}
+
+// MarshalJSON implements json.Marshaler. The set is encoded as an array of its elements in
+// ascending order.
+func (s Set) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Sorted())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It replaces the contents of the set.
+func (s *Set) UnmarshalJSON(data []byte) error {
+	var elts []int
+	if err := json.Unmarshal(data, &elts); err != nil {
+		return err
+	}
+	s.load(elts)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, using the same form as String.
+func (s Set) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It parses the form produced by String, such
+// as {1, 2, 3}, and replaces the contents of the set.
+func (s *Set) UnmarshalText(text []byte) error {
+	str := strings.TrimSpace(string(text))
+	if len(str) < 2 || str[0] != '{' || str[len(str)-1] != '}' {
+		return fmt.Errorf("%w: %q isn't enclosed in braces", ErrBadEncoding, str)
+	}
+	str = strings.TrimSpace(str[1 : len(str)-1])
+	var elts []int
+	if str != "" {
+		for _, f := range strings.Split(str, ",") {
+			e, err := strconv.Atoi(strings.TrimSpace(f))
+			if err != nil {
+				return fmt.Errorf("%w: bad element %q", ErrBadEncoding, f)
+			}
+			elts = append(elts, e)
 		}
-		res[i] = k
-		i++
 	}
-	return res
+	s.load(elts)
+	return nil
+}
+
+// setVersion is the version of the binary encoding.
+const setVersion = 1
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is a version byte and the
+// number of elements as a uvarint, then the elements in ascending order, the first as a varint and
+// the rest as uvarint deltas from the one before.
+func (s Set) MarshalBinary() ([]byte, error) {
+	elts := s.Sorted()
+	res := []byte{setVersion}
+	res = binary.AppendUvarint(res, uint64(len(elts)))
+	for i, e := range elts {
+		if i == 0 {
+			res = binary.AppendVarint(res, int64(e))
+		} else {
+			res = binary.AppendUvarint(res, uint64(e)-uint64(elts[i-1]))
+		}
+	}
+	return res, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It replaces the contents of the set.
+func (s *Set) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != setVersion {
+		return ErrBadEncoding
+	}
+	data = data[1:]
+	n, l := binary.Uvarint(data)
+	if l <= 0 || n > uint64(len(data)) {
+		return ErrBadEncoding
+	}
+	data = data[l:]
+	elts := make([]int, 0, n)
+	for i := uint64(0); i < n; i++ {
+		var e int
+		if i == 0 {
+			v, l := binary.Varint(data)
+			if l <= 0 {
+				return ErrBadEncoding
+			}
+			e, data = int(v), data[l:]
+		} else {
+			d, l := binary.Uvarint(data)
+			if l <= 0 || d == 0 {
+				return ErrBadEncoding
+			}
+			e, data = int(uint64(elts[i-1])+d), data[l:]
+		}
+		elts = append(elts, e)
+	}
+	if len(data) != 0 {
+		return ErrBadEncoding
+	}
+	s.load(elts)
+	return nil
+}
+
+// load replaces the contents of the set with elts. An existing map is reused so that copies of s
+// see the change.
+func (s *Set) load(elts []int) {
+	if *s == nil {
+		*s = make(Set, len(elts))
+	} else {
+		clear(*s)
+	}
+	for _, e := range elts {
+		(*s)[e] = true
+	}
+}
+
+// Min returns the smallest element of the set. If the set is empty, false is returned.
+func (s Set) Min() (int, bool) {
+	return SetMin(s)
 }
 
+// Max returns the largest element of the set. If the set is empty, false is returned.
+func (s Set) Max() (int, bool) {
+	return SetMax(s)
+}
+
+// Between returns the elements e of the set where a <= e <= b in ascending order.
+func (s Set) Between(a, b int) []int {
+	return SetBetween(s, a, b)
+}
+
+// The package functions work with both Set and SetOf, and any other map[T]bool based set type.
+
 // Union returns a new set containing the union of a and b (OR).
-func Union(a, b Set) Set {
-	res := make(Set)
+func Union[S ~map[T]bool, T comparable](a, b S) S {
+	res := make(S)
 	for e, v := range a {
 		if !v {
 			continue
@@ -165,10 +290,19 @@ func Union(a, b Set) Set {
 	return res
 }
 
+// UnionAll returns a new set containing the union of all the sets (OR).
+func UnionAll[S ~map[T]bool, T comparable](sets ...S) S {
+	res := make(S)
+	for _, s := range sets {
+		setUnionWith(res, s)
+	}
+	return res
+}
+
 // Intersection returns a new set containing the intersection of a and b (AND).
-func Intersection(a, b Set) Set {
-	res := make(Set)
-	la, lb := a.Len(), b.Len()
+func Intersection[S ~map[T]bool, T comparable](a, b S) S {
+	res := make(S)
+	la, lb := setLen(a), setLen(b)
 	if la < lb {
 		for e, v := range a {
 			if !v {
@@ -191,10 +325,44 @@ func Intersection(a, b Set) Set {
 	return res
 }
 
+// IntersectAll returns a new set containing the intersection of all the sets (AND). The smallest
+// set is used to drive the intersection.
+func IntersectAll[S ~map[T]bool, T comparable](sets ...S) S {
+	res := make(S)
+	if len(sets) == 0 {
+		return res
+	}
+	si, sl := 0, setLen(sets[0])
+	for i, s := range sets[1:] {
+		if l := setLen(s); l < sl {
+			si, sl = i+1, l
+		}
+	}
+	if sl == 0 {
+		return res
+	}
+	for e, v := range sets[si] {
+		if !v {
+			continue
+		}
+		in := true
+		for i, s := range sets {
+			if i != si && !s[e] {
+				in = false
+				break
+			}
+		}
+		if in {
+			res[e] = true
+		}
+	}
+	return res
+}
+
 // Difference returns a new set containing only the elements in either a or b but not in both (XOR).
-func Difference(a, b Set) Set {
+func Difference[S ~map[T]bool, T comparable](a, b S) S {
 	// return Sub(Union(a, b), Intersection(a, b))
-	res := make(Set)
+	res := make(S)
 	for e, v := range a {
 		if !v {
 			continue
@@ -215,8 +383,8 @@ func Difference(a, b Set) Set {
 }
 
 // Sub returns a new set containing the elements in a which are not in b (SUB).
-func Sub(a, b Set) Set {
-	res := make(Set)
+func Sub[S ~map[T]bool, T comparable](a, b S) S {
+	res := make(S)
 	for e, v := range a {
 		if !v {
 			continue
@@ -228,8 +396,17 @@ func Sub(a, b Set) Set {
 	return res
 }
 
+// SubAll returns a new set containing the elements in a which are not in any of the others (SUB).
+func SubAll[S ~map[T]bool, T comparable](a S, others ...S) S {
+	res := setCopy(a)
+	for _, s := range others {
+		setSubWith(res, s)
+	}
+	return res
+}
+
 // Contains returns true if b is completely contained in a.
-func Contains(a, b Set) bool {
+func Contains[S ~map[T]bool, T comparable](a, b S) bool {
 	for e, v := range b {
 		if !v {
 			continue
@@ -242,9 +419,9 @@ func Contains(a, b Set) bool {
 }
 
 // Disjoint returns true if a and b share no elements in common.
-func Disjoint(a, b Set) bool {
+func Disjoint[S ~map[T]bool, T comparable](a, b S) bool {
 	// return Intersection(a, b).Empty()
-	la, lb := a.Len(), b.Len()
+	la, lb := setLen(a), setLen(b)
 	if la < lb {
 		for e, v := range a {
 			if !v {
@@ -266,3 +443,191 @@ func Disjoint(a, b Set) bool {
 	}
 	return true
 }
+
+// Jaccard returns the Jaccard similarity of a and b, the size of their intersection divided by
+// the size of their union. Two empty sets have a similarity of 1.
+func Jaccard[S ~map[T]bool, T comparable](a, b S) float64 {
+	la, lb := setLen(a), setLen(b)
+	if la == 0 && lb == 0 {
+		return 1
+	}
+	small, large := a, b
+	if la > lb {
+		small, large = b, a
+	}
+	n := 0
+	for e, v := range small {
+		if v && large[e] {
+			n++
+		}
+	}
+	return float64(n) / float64(la+lb-n)
+}
+
+// SetSorted returns a slice representation of s in ascending order.
+func SetSorted[S ~map[T]bool, T cmp.Ordered](s S) []T {
+	res := setSlice(s)
+	slices.Sort(res)
+	return res
+}
+
+// SetMin returns the smallest element of s. If s is empty, false is returned.
+func SetMin[S ~map[T]bool, T cmp.Ordered](s S) (T, bool) {
+	var res T
+	found := false
+	for e, v := range s {
+		if v && (!found || e < res) {
+			res, found = e, true
+		}
+	}
+	return res, found
+}
+
+// SetMax returns the largest element of s. If s is empty, false is returned.
+func SetMax[S ~map[T]bool, T cmp.Ordered](s S) (T, bool) {
+	var res T
+	found := false
+	for e, v := range s {
+		if v && (!found || e > res) {
+			res, found = e, true
+		}
+	}
+	return res, found
+}
+
+// SetBetween returns the elements e of s where a <= e <= b in ascending order.
+func SetBetween[S ~map[T]bool, T cmp.Ordered](s S, a, b T) []T {
+	res := []T{}
+	for e, v := range s {
+		if v && e >= a && e <= b {
+			res = append(res, e)
+		}
+	}
+	slices.Sort(res)
+	return res
+}
+
+// Helpers shared by the map based set types.
+
+func setAdd[T comparable](s map[T]bool, e T) bool {
+	if s[e] {
+		return false
+	}
+	// Also revives a removed element
+	s[e] = true
+	return true
+}
+
+func setRemove[T comparable](s map[T]bool, e T) bool {
+	v, ok := s[e]
+	if !ok || !v {
+		return false
+	}
+	s[e] = false
+	//delete(s, e)
+	return true
+}
+
+func setLen[T comparable](s map[T]bool) int {
+	if len(s) == 0 {
+		return 0
+	}
+	n := 0
+	for _, v := range s {
+		if v {
+			n++
+		}
+	}
+	return n
+}
+
+func setCopy[S ~map[T]bool, T comparable](s S) S {
+	res := make(S)
+	for k, v := range s {
+		if v {
+			res[k] = v
+		}
+	}
+	return res
+}
+
+func setPurge[T comparable](s map[T]bool) {
+	for k, v := range s {
+		if !v {
+			delete(s, k)
+		}
+	}
+}
+
+// The in place operations delete elements rather than marking them as removed.
+
+func setUnionWith[T comparable](s, b map[T]bool) {
+	for e, v := range b {
+		if v {
+			s[e] = true
+		}
+	}
+}
+
+func setIntersectWith[T comparable](s, b map[T]bool) {
+	for e, v := range s {
+		if !v || !b[e] {
+			delete(s, e)
+		}
+	}
+}
+
+func setDifferenceWith[T comparable](s, b map[T]bool) {
+	for e, v := range b {
+		if !v {
+			continue
+		}
+		if s[e] {
+			delete(s, e)
+		} else {
+			s[e] = true
+		}
+	}
+}
+
+func setSubWith[T comparable](s, b map[T]bool) {
+	for e, v := range b {
+		if v {
+			delete(s, e)
+		}
+	}
+}
+
+func setString[T comparable](s map[T]bool) string {
+	if setLen(s) == 0 {
+		return "{}"
+	}
+	res := "{"
+	first := true
+	for k, v := range s {
+		if !v {
+			continue
+		}
+		if first {
+			res += fmt.Sprintf("%v", k)
+			first = false
+		} else {
+			res += fmt.Sprintf(", %v", k)
+		}
+	}
+	return res + "}"
+}
+
+func setSlice[T comparable](s map[T]bool) []T {
+	n := setLen(s)
+	res := make([]T, n)
+	i := 0
+	for k, v := range s {
+		if !v {
+			continue
+		}
+		res[i] = k
+		i++
+	}
+	return res
+}
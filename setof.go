@@ -0,0 +1,111 @@
+package datastruct
+
+// SetOf represents a set of elements of any comparable type. It has the same operations as Set,
+// which is the equivalent of SetOf[int].
+type SetOf[T comparable] map[T]bool
+
+// NewSetOf returns a new set with the provided elements in it.
+func NewSetOf[T comparable](elts ...T) SetOf[T] {
+	res := make(SetOf[T])
+	for _, e := range elts {
+		res[e] = true
+	}
+	return res
+}
+
+// Add adds the element e to the set and returns true if it wasn't already in the set. Adding
+// an element when it already exists is a no-op signified by false.
+func (s SetOf[T]) Add(e T) bool {
+	return setAdd(s, e)
+}
+
+// Remove removes element e from the set, if it exists by setting it to false, and returns true if it was in the set.
+// Removing a non-existent element is a no-op signified by false. Use Purge() to actually shrink the set.
+func (s SetOf[T]) Remove(e T) bool {
+	return setRemove(s, e)
+}
+
+// Element returns true if the set contains the element e.
+func (s SetOf[T]) Element(e T) bool {
+	return s[e]
+}
+
+// Empty returns true if the set is the empty set.
+func (s SetOf[T]) Empty() bool {
+	return s.Len() == 0
+}
+
+// Len returns the number of elements in the set.
+func (s SetOf[T]) Len() int {
+	return setLen(s)
+}
+
+// Copy makes a copy of the set.
+func (s SetOf[T]) Copy() SetOf[T] {
+	return setCopy(s)
+}
+
+// Purge clears out removed entries from the set.
+func (s SetOf[T]) Purge() {
+	setPurge(s)
+}
+
+// Union returns a new set containing the union of the set and b (OR).
+func (s SetOf[T]) Union(b SetOf[T]) SetOf[T] {
+	return Union(s, b)
+}
+
+// Intersection returns a new set containing the intersection of the set and b (AND).
+func (s SetOf[T]) Intersection(b SetOf[T]) SetOf[T] {
+	return Intersection(s, b)
+}
+
+// Difference returns a new set containing only the elements in either the set or b but not in both (XOR).
+func (s SetOf[T]) Difference(b SetOf[T]) SetOf[T] {
+	return Difference(s, b)
+}
+
+// Sub returns a new set containing the elements in the set which are not in b (SUB).
+func (s SetOf[T]) Sub(b SetOf[T]) SetOf[T] {
+	return Sub(s, b)
+}
+
+// Contains returns true if b is completely contained in the set.
+func (s SetOf[T]) Contains(b SetOf[T]) bool {
+	return Contains(s, b)
+}
+
+// Disjoint returns true if the set and b share no elements in common.
+func (s SetOf[T]) Disjoint(b SetOf[T]) bool {
+	return Disjoint(s, b)
+}
+
+// UnionWith adds the elements of b to the set (OR).
+func (s SetOf[T]) UnionWith(b SetOf[T]) {
+	setUnionWith(s, b)
+}
+
+// IntersectWith removes the elements from the set that aren't in b (AND).
+func (s SetOf[T]) IntersectWith(b SetOf[T]) {
+	setIntersectWith(s, b)
+}
+
+// DifferenceWith leaves only the elements in either the set or b but not in both (XOR).
+func (s SetOf[T]) DifferenceWith(b SetOf[T]) {
+	setDifferenceWith(s, b)
+}
+
+// SubWith removes the elements in b from the set (SUB).
+func (s SetOf[T]) SubWith(b SetOf[T]) {
+	setSubWith(s, b)
+}
+
+// String returns a string representation of the set.
+func (s SetOf[T]) String() string {
+	return setString(s)
+}
+
+// Slice returns an unsorted slice representation of the set.
+func (s SetOf[T]) Slice() []T {
+	return setSlice(s)
+}
@@ -0,0 +1,204 @@
+package datastruct
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+)
+
+// SortedSet represents a set of ordered elements, backed by a skip list, that keeps its elements
+// in ascending order. Add, Remove, Element, Ceiling and Floor are O(log n), and iteration, in
+// order, is O(1) per element. Use NewSortedSet to create one.
+type SortedSet[T cmp.Ordered] struct {
+	head  *ssnode[T] // sentinel, has plMaxLevel levels
+	level int
+	n     int
+}
+
+type ssnode[T cmp.Ordered] struct {
+	elt  T
+	next []*ssnode[T]
+}
+
+// NewSortedSet returns a new set with the provided elements in it.
+func NewSortedSet[T cmp.Ordered](elts ...T) *SortedSet[T] {
+	res := &SortedSet[T]{&ssnode[T]{next: make([]*ssnode[T], plMaxLevel)}, 1, 0}
+	for _, e := range elts {
+		res.Add(e)
+	}
+	return res
+}
+
+// Add adds the element e to the set and returns true if it wasn't already in the set. Adding
+// an element when it already exists is a no-op signified by false.
+func (s *SortedSet[T]) Add(e T) bool {
+	update, x := s.find(e)
+	if x != nil && x.elt == e {
+		return false
+	}
+	lvl := plRandomLevel()
+	if lvl > s.level {
+		for i := s.level; i < lvl; i++ {
+			update[i] = s.head
+		}
+		s.level = lvl
+	}
+	node := &ssnode[T]{e, make([]*ssnode[T], lvl)}
+	for i := 0; i < lvl; i++ {
+		node.next[i] = update[i].next[i]
+		update[i].next[i] = node
+	}
+	s.n++
+	return true
+}
+
+// Remove removes element e from the set and returns true if it was in the set.
+// Removing a non-existent element is a no-op signified by false.
+func (s *SortedSet[T]) Remove(e T) bool {
+	update, x := s.find(e)
+	if x == nil || x.elt != e {
+		return false
+	}
+	for i := 0; i < s.level && update[i].next[i] == x; i++ {
+		update[i].next[i] = x.next[i]
+	}
+	for s.level > 1 && s.head.next[s.level-1] == nil {
+		s.level--
+	}
+	s.n--
+	return true
+}
+
+// Element returns true if the set contains the element e.
+func (s *SortedSet[T]) Element(e T) bool {
+	_, x := s.find(e)
+	return x != nil && x.elt == e
+}
+
+// Empty returns true if the set is the empty set.
+func (s *SortedSet[T]) Empty() bool {
+	return s.n == 0
+}
+
+// Len returns the number of elements in the set.
+func (s *SortedSet[T]) Len() int {
+	return s.n
+}
+
+// Copy makes a copy of the set.
+func (s *SortedSet[T]) Copy() *SortedSet[T] {
+	res := NewSortedSet[T]()
+	for e := range s.All() {
+		res.Add(e)
+	}
+	return res
+}
+
+// Min returns the smallest element of the set. If the set is empty, false is returned.
+func (s *SortedSet[T]) Min() (T, bool) {
+	if x := s.head.next[0]; x != nil {
+		return x.elt, true
+	}
+	var res T
+	return res, false
+}
+
+// Max returns the largest element of the set. If the set is empty, false is returned.
+func (s *SortedSet[T]) Max() (T, bool) {
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil {
+			x = x.next[i]
+		}
+	}
+	return x.elt, x != s.head
+}
+
+// Ceiling returns the smallest element of the set greater than or equal to e. If there isn't one,
+// false is returned.
+func (s *SortedSet[T]) Ceiling(e T) (T, bool) {
+	if _, x := s.find(e); x != nil {
+		return x.elt, true
+	}
+	var res T
+	return res, false
+}
+
+// Floor returns the largest element of the set less than or equal to e. If there isn't one,
+// false is returned.
+func (s *SortedSet[T]) Floor(e T) (T, bool) {
+	update, x := s.find(e)
+	if x != nil && x.elt == e {
+		return e, true
+	}
+	return update[0].elt, update[0] != s.head
+}
+
+// All returns an iterator over the elements of the set in ascending order.
+func (s *SortedSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for x := s.head.next[0]; x != nil; x = x.next[0] {
+			if !yield(x.elt) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over the elements e of the set where a <= e <= b in ascending order.
+func (s *SortedSet[T]) Range(a, b T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, x := s.find(a); x != nil && x.elt <= b; x = x.next[0] {
+			if !yield(x.elt) {
+				return
+			}
+		}
+	}
+}
+
+// Between returns the elements e of the set where a <= e <= b in ascending order.
+func (s *SortedSet[T]) Between(a, b T) []T {
+	res := []T{}
+	for e := range s.Range(a, b) {
+		res = append(res, e)
+	}
+	return res
+}
+
+// Slice returns a slice representation of the set in ascending order.
+func (s *SortedSet[T]) Slice() []T {
+	res := make([]T, 0, s.n)
+	for e := range s.All() {
+		res = append(res, e)
+	}
+	return res
+}
+
+// String returns a string representation of the set in ascending order.
+func (s *SortedSet[T]) String() string {
+	res := "{"
+	first := true
+	for e := range s.All() {
+		if first {
+			res += fmt.Sprintf("%v", e)
+			first = false
+		} else {
+			res += fmt.Sprintf(", %v", e)
+		}
+	}
+	return res + "}"
+}
+
+// find returns the predecessors at each level of the first node with an element not less than e,
+// and that node (or nil).
+func (s *SortedSet[T]) find(e T) ([plMaxLevel]*ssnode[T], *ssnode[T]) {
+	var update [plMaxLevel]*ssnode[T]
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].elt < e {
+			x = x.next[i]
+		}
+		update[i] = x
+	}
+	return update, x.next[0]
+}
@@ -0,0 +1,127 @@
+package datastruct
+
+import (
+	"container/heap"
+	"slices"
+)
+
+// TopK collects the k best items added to it, where best is the lowest priority, or the highest
+// if Max is set. Once k items are held, adding a better item evicts the worst one. Add is
+// O(log k). A TopK isn't safe for concurrent use; instead collect partial results per goroutine
+// and Merge them.
+type TopK struct {
+	K     int
+	items topkHeap
+}
+
+// NewTopK creates a new TopK that holds up to k items, keeping the highest priorities if max is
+// true, or the lowest otherwise.
+func NewTopK(k int, max bool) *TopK {
+	return &TopK{k, topkHeap{max: max}}
+}
+
+// Max returns true if the highest priorities are being kept.
+func (t *TopK) Max() bool {
+	return t.items.max
+}
+
+// Len returns the number of items held
+func (t *TopK) Len() int {
+	return len(t.items.items)
+}
+
+// Add offers an item with id and priority and returns true if it was kept.
+func (t *TopK) Add(id int, pri float64) bool {
+	if t.K < 1 {
+		return false
+	}
+	itm := PriorityItem{pri, id, -1}
+	if len(t.items.items) < t.K {
+		heap.Push(&t.items, itm)
+		return true
+	}
+	// Root is the worst item
+	if !t.items.better(itm, t.items.items[0]) {
+		return false
+	}
+	t.items.items[0] = itm
+	heap.Fix(&t.items, 0)
+	return true
+}
+
+// Worst returns the priority of the worst item held, which is the priority a new item has to beat
+// once the TopK is full. If nothing is held, false is returned.
+func (t *TopK) Worst() (float64, bool) {
+	if len(t.items.items) == 0 {
+		return 0, false
+	}
+	return t.items.items[0].Priority, true
+}
+
+// Sorted returns the items held, best first.
+func (t *TopK) Sorted() []PriorityItem {
+	res := slices.Clone(t.items.items)
+	slices.SortFunc(res, func(a, b PriorityItem) int {
+		switch {
+		case t.items.better(a, b):
+			return -1
+		case t.items.better(b, a):
+			return 1
+		}
+		return 0
+	})
+	return res
+}
+
+// Merge adds the items from the other TopKs, such as partial results computed by different
+// goroutines. The other TopKs are unchanged. Merging t with itself has no effect.
+func (t *TopK) Merge(others ...*TopK) {
+	for _, o := range others {
+		if o == t {
+			continue
+		}
+		for _, itm := range o.items.items {
+			t.Add(itm.Id, itm.Priority)
+		}
+	}
+}
+
+// Reset removes all the items held.
+func (t *TopK) Reset() {
+	t.items.items = t.items.items[:0]
+}
+
+// topkHeap keeps the worst item at the root. It supports heap.Interface.
+type topkHeap struct {
+	items []PriorityItem
+	max   bool
+}
+
+// better returns true if a should be kept in preference to b.
+func (h *topkHeap) better(a, b PriorityItem) bool {
+	if h.max {
+		return a.Priority > b.Priority
+	}
+	return a.Priority < b.Priority
+}
+
+func (h *topkHeap) Len() int { return len(h.items) }
+
+func (h *topkHeap) Less(i, j int) bool {
+	return h.better(h.items[j], h.items[i])
+}
+
+func (h *topkHeap) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *topkHeap) Push(x any) {
+	h.items = append(h.items, x.(PriorityItem))
+}
+
+func (h *topkHeap) Pop() any {
+	n := len(h.items)
+	itm := h.items[n-1]
+	h.items = h.items[:n-1]
+	return itm
+}
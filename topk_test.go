@@ -0,0 +1,25 @@
+package datastruct
+
+import "testing"
+
+func TestTopKMergeSelf(t *testing.T) {
+	tk := NewTopK(4, false)
+	tk.Add(1, 1)
+	tk.Add(2, 2)
+	other := NewTopK(4, false)
+	other.Add(3, 0)
+	tk.Merge(tk, other)
+	got := tk.Sorted()
+	want := []int{3, 1, 2}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want ids %v", got, want)
+	}
+	for i, itm := range got {
+		if itm.Id != want[i] {
+			t.Fatalf("got %v, want ids %v", got, want)
+		}
+	}
+	if other.Len() != 1 {
+		t.Errorf("other changed, has %d items", other.Len())
+	}
+}